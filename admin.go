@@ -0,0 +1,143 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// errorResponse is the body of the error responses of the handlers.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// AdminHandler returns an HTTP handler exposing JSON endpoints to manage the
+// targets of the checker at runtime:
+//
+//	GET    /targets                    lists the targets
+//	POST   /targets/{name}/check       checks a target immediately
+//	POST   /targets/{name}/mute        mutes the notifications of a target
+//	POST   /targets/{name}/unmute      unmutes the notifications of a target
+//	POST   /targets/{name}/disable     disables a target
+//	POST   /targets/{name}/enable      enables a target
+//	PUT    /targets/{name}/maintenance schedules the maintenance of a target
+//	DELETE /targets/{name}/maintenance ends the maintenance of a target
+//	GET    /maintenance                returns the maintenance of the checker
+//	PUT    /maintenance                schedules the maintenance of the checker
+//	DELETE /maintenance                ends the maintenance of the checker
+//
+// Maintenance is described with a JSON Maintenance object in the request
+// body; it starts right away unless a start time is given. The handler does
+// no authentication, so it is meant to be mounted separately from the public
+// handlers, e.g. with http.StripPrefix, behind an authenticating middleware.
+func (c *HealthChecker) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /targets", func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, c.Targets())
+	})
+
+	mux.HandleFunc("POST /targets/{name}/check", func(w http.ResponseWriter, r *http.Request) {
+		result, err := c.CheckTarget(r.Context(), r.PathValue("name"))
+		if err != nil {
+			respondAdminError(w, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, result)
+	})
+
+	targetAction := func(action func(name string) error) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if err := action(r.PathValue("name")); err != nil {
+				respondAdminError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	mux.HandleFunc("POST /targets/{name}/mute", targetAction(c.MuteTarget))
+	mux.HandleFunc("POST /targets/{name}/unmute", targetAction(c.UnmuteTarget))
+	mux.HandleFunc("POST /targets/{name}/disable", targetAction(c.DisableTarget))
+	mux.HandleFunc("POST /targets/{name}/enable", targetAction(c.EnableTarget))
+	mux.HandleFunc("DELETE /targets/{name}/maintenance", targetAction(c.EndTargetMaintenance))
+
+	mux.HandleFunc("PUT /targets/{name}/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		m, err := decodeMaintenance(r)
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		if err := c.ScheduleTargetMaintenance(r.PathValue("name"), m); err != nil {
+			respondAdminError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /maintenance", func(w http.ResponseWriter, r *http.Request) {
+		m, ok := c.ScheduledMaintenance()
+		if !ok {
+			respondJSON(w, http.StatusOK, nil)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, m)
+	})
+
+	mux.HandleFunc("PUT /maintenance", func(w http.ResponseWriter, r *http.Request) {
+		m, err := decodeMaintenance(r)
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		if err := c.ScheduleMaintenance(m); err != nil {
+			respondAdminError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("DELETE /maintenance", func(w http.ResponseWriter, r *http.Request) {
+		c.EndMaintenance()
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
+
+// decodeMaintenance decodes the maintenance from the body of the request.
+func decodeMaintenance(r *http.Request) (Maintenance, error) {
+	var m Maintenance
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		return Maintenance{}, fmt.Errorf("decoding maintenance: %w", err)
+	}
+
+	if m.Reason == "" {
+		return Maintenance{}, errors.New("maintenance reason is required")
+	}
+
+	return m, nil
+}
+
+// respondAdminError responds with the error, using 404 for unknown targets
+// and 400 for invalid maintenance.
+func respondAdminError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrTargetNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, ErrInvalidMaintenance):
+		code = http.StatusBadRequest
+	}
+
+	respondJSON(w, code, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,266 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_AdminHandler(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		}, WithGroup("Databases")).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	handler := http.StripPrefix("/admin", checker.AdminHandler())
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{"mute", http.MethodPost, "/admin/targets/cache/mute", "", http.StatusNoContent, ""},
+		{"mute unknown target", http.MethodPost, "/admin/targets/queue/mute", "", http.StatusNotFound, `{"error":"muting target queue: target not found"}`},
+		{"disable", http.MethodPost, "/admin/targets/cache/disable", "", http.StatusNoContent, ""},
+		{"target maintenance", http.MethodPut, "/admin/targets/database/maintenance", `{"reason":"upgrade","until":"2099-01-01T00:00:00Z"}`, http.StatusNoContent, ""},
+		{"target maintenance without reason", http.MethodPut, "/admin/targets/database/maintenance", `{}`, http.StatusBadRequest, `{"error":"maintenance reason is required"}`},
+		{"invalid maintenance", http.MethodPut, "/admin/maintenance", `{`, http.StatusBadRequest, ""},
+		{"maintenance ending before start", http.MethodPut, "/admin/maintenance", `{"reason":"upgrade","start":"2099-01-02T00:00:00Z","until":"2099-01-01T00:00:00Z"}`, http.StatusBadRequest, `{"error":"maintenance from 2099-01-02T00:00:00Z until 2099-01-01T00:00:00Z: maintenance ends before it starts"}`},
+		{"no maintenance", http.MethodGet, "/admin/maintenance", "", http.StatusOK, "null"},
+		{"wrong method", http.MethodGet, "/admin/targets/cache/mute", "", http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, tt := range tests {
+		w := serve(tt.method, tt.path, tt.body)
+
+		if w.Code != tt.expectedCode {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.expectedCode, w.Code)
+		}
+
+		if tt.expectedBody != "" && strings.TrimSpace(w.Body.String()) != tt.expectedBody {
+			t.Errorf("%s: expected body %s, got %s", tt.name, tt.expectedBody, w.Body.String())
+		}
+	}
+
+	w := serve(http.MethodGet, "/admin/targets", "")
+
+	var targets []TargetInfo
+	if err := json.NewDecoder(w.Body).Decode(&targets); err != nil {
+		t.Fatalf("failed to decode targets: %v", err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+
+	if targets[0].Name != "database" || targets[0].Group != "Databases" || !targets[0].Enabled ||
+		targets[0].Maintenance == nil || targets[0].Maintenance.Reason != "upgrade" {
+		t.Errorf("unexpected database target %+v", targets[0])
+	}
+
+	if targets[1].Name != "cache" || targets[1].Enabled || !targets[1].Muted {
+		t.Errorf("unexpected cache target %+v", targets[1])
+	}
+
+	w = serve(http.MethodPost, "/admin/targets/database/check", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var result HealthCheckResult
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+
+	if result.Status != HealthTargetStatusMaintenance || result.RawStatus != HealthTargetStatusOk || calls.Load() != 1 {
+		t.Errorf("expected the target to be checked once under maintenance, got %+v after %d calls", result, calls.Load())
+	}
+
+	if w := serve(http.MethodPost, "/admin/targets/cache/check", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected disabled target check to respond %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	serve(http.MethodPut, "/admin/maintenance", `{"reason":"datacenter move"}`)
+
+	w = serve(http.MethodGet, "/admin/maintenance", "")
+
+	var m Maintenance
+	if err := json.NewDecoder(w.Body).Decode(&m); err != nil {
+		t.Fatalf("failed to decode maintenance: %v", err)
+	}
+	if m.Reason != "datacenter move" {
+		t.Errorf("expected checker maintenance, got %+v", m)
+	}
+
+	serve(http.MethodPut, "/admin/maintenance", `{"reason":"network upgrade","start":"2099-01-01T00:00:00Z"}`)
+
+	if _, ok := checker.Maintenance(); ok {
+		t.Error("expected scheduled checker maintenance not to be in progress")
+	}
+
+	w = serve(http.MethodGet, "/admin/maintenance", "")
+
+	m = Maintenance{}
+	if err := json.NewDecoder(w.Body).Decode(&m); err != nil {
+		t.Fatalf("failed to decode maintenance: %v", err)
+	}
+	if m.Reason != "network upgrade" || m.Start.IsZero() {
+		t.Errorf("expected scheduled checker maintenance, got %+v", m)
+	}
+
+	serve(http.MethodDelete, "/admin/maintenance", "")
+	serve(http.MethodDelete, "/admin/targets/database/maintenance", "")
+	serve(http.MethodPost, "/admin/targets/cache/enable", "")
+	serve(http.MethodPost, "/admin/targets/cache/unmute", "")
+
+	for _, target := range checker.Targets() {
+		if !target.Enabled || target.Muted || target.Maintenance != nil {
+			t.Errorf("expected %s to be restored, got %+v", target.Name, target)
+		}
+	}
+
+	if _, ok := checker.Maintenance(); ok {
+		t.Error("expected checker maintenance to end")
+	}
+}
+
+func TestHealthChecker_CheckTargetWhileStarted(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	checker := NewHealthChecker(WithCheckInterval(time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer checker.Stop()
+
+	failing.Store(true)
+
+	result, err := checker.CheckTarget(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != HealthTargetStatusFail {
+		t.Errorf("expected fail status, got %s", result.Status)
+	}
+
+	results, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusFail {
+		t.Errorf("expected the cached result to be updated, got %s", results[0].Status)
+	}
+}
+
+func TestHealthChecker_CheckTargetCancelled(t *testing.T) {
+	t.Parallel()
+
+	var hanging atomic.Bool
+
+	checker := NewHealthChecker(WithCheckInterval(time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			if hanging.Load() {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		})
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer checker.Stop()
+
+	hanging.Store(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	result, err := checker.CheckTarget(ctx, "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != HealthTargetStatusFail {
+		t.Errorf("expected fail status, got %s", result.Status)
+	}
+
+	results, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected the cached result to be kept, got %s: %s", results[0].Status, results[0].ErrorMessage)
+	}
+}
+
+func TestHealthChecker_MuteTarget(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	events := make(chan TransitionEvent, 10)
+
+	checker := NewHealthChecker(
+		WithNotifier(NotifierFunc(func(_ context.Context, event TransitionEvent) error {
+			events <- event
+			return nil
+		})),
+	).WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := checker.MuteTarget("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	failing.Store(true)
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
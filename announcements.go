@@ -0,0 +1,156 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+)
+
+// defaultIncidentRetention is how long resolved incidents stay on the status
+// page when the page doesn't configure it.
+const defaultIncidentRetention = 24 * time.Hour
+
+// IncidentSeverity represents the severity of an incident.
+type IncidentSeverity string
+
+const (
+	// IncidentSeverityMinor indicates an incident with limited impact.
+	IncidentSeverityMinor = IncidentSeverity("minor")
+	// IncidentSeverityMajor indicates an incident affecting a significant
+	// part of the system.
+	IncidentSeverityMajor = IncidentSeverity("major")
+	// IncidentSeverityCritical indicates an outage.
+	IncidentSeverityCritical = IncidentSeverity("critical")
+)
+
+// Incident is an incident announced on the status page.
+type Incident struct {
+	Title    string           `json:"title"`
+	Severity IncidentSeverity `json:"severity"`
+	// Targets are the names of the affected targets.
+	Targets []string `json:"targets,omitempty"`
+	// Updates is the timeline of the incident, oldest first.
+	Updates    []IncidentUpdate `json:"updates,omitempty"`
+	ResolvedAt time.Time        `json:"resolved_at,omitzero"`
+}
+
+// Resolved reports whether the incident is resolved.
+func (i Incident) Resolved() bool {
+	return !i.ResolvedAt.IsZero()
+}
+
+// IncidentUpdate is an entry of the timeline of an incident.
+type IncidentUpdate struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// ScheduledMaintenance is a planned maintenance announced on the status page.
+type ScheduledMaintenance struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	// Targets are the names of the affected targets.
+	Targets []string `json:"targets,omitempty"`
+}
+
+// InProgress reports whether the maintenance is in progress at the given
+// time.
+func (m ScheduledMaintenance) InProgress(now time.Time) bool {
+	return !now.Before(m.Start) && now.Before(m.End)
+}
+
+// Announcements are the incidents and scheduled maintenances announced on
+// the status page.
+type Announcements struct {
+	Incidents             []Incident             `json:"incidents,omitempty"`
+	ScheduledMaintenances []ScheduledMaintenance `json:"scheduled_maintenances,omitempty"`
+}
+
+// LoadAnnouncements reads the announcements from a JSON file.
+func LoadAnnouncements(path string) (Announcements, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Announcements{}, fmt.Errorf("reading announcements: %w", err)
+	}
+
+	var a Announcements
+	if err := json.Unmarshal(data, &a); err != nil {
+		return Announcements{}, fmt.Errorf("decoding announcements: %w", err)
+	}
+
+	return a, nil
+}
+
+// WithIncident announces an incident on the status page.
+func WithIncident(incident Incident) PageOption {
+	return func(p *Page) {
+		p.announcements.Incidents = append(p.announcements.Incidents, incident)
+	}
+}
+
+// WithScheduledMaintenance announces a planned maintenance on the status
+// page. It is shown until it ends.
+func WithScheduledMaintenance(maintenance ScheduledMaintenance) PageOption {
+	return func(p *Page) {
+		p.announcements.ScheduledMaintenances = append(p.announcements.ScheduledMaintenances, maintenance)
+	}
+}
+
+// WithResolvedIncidentRetention sets how long resolved incidents stay on the
+// status page. Defaults to 24 hours.
+func WithResolvedIncidentRetention(retention time.Duration) PageOption {
+	return func(p *Page) {
+		p.incidentRetention = retention
+	}
+}
+
+// WithAnnouncementsFile announces the incidents and scheduled maintenances
+// read from a JSON file, in addition to the ones configured in code. The file
+// is read on every request, so it can be edited while the page is served. If
+// the file can't be read or decoded, the error is logged and the page is
+// rendered without its announcements.
+func WithAnnouncementsFile(path string) PageOption {
+	return func(p *Page) {
+		p.announcementsFile = path
+	}
+}
+
+// currentAnnouncements returns the announcements of the page, leaving out the
+// scheduled maintenances that have ended and the incidents resolved longer
+// than the retention ago. An announcements file that can't be loaded is
+// logged and ignored, so that the page stays up.
+func (p *Page) currentAnnouncements(now time.Time) Announcements {
+	incidents := slices.Clone(p.announcements.Incidents)
+	maintenances := slices.Clone(p.announcements.ScheduledMaintenances)
+
+	if p.announcementsFile != "" {
+		loaded, err := LoadAnnouncements(p.announcementsFile)
+		if err != nil {
+			log.Printf("loading announcements: %v", err)
+		}
+
+		incidents = append(incidents, loaded.Incidents...)
+		maintenances = append(maintenances, loaded.ScheduledMaintenances...)
+	}
+
+	var a Announcements
+
+	for _, i := range incidents {
+		if !i.Resolved() || now.Sub(i.ResolvedAt) < p.incidentRetention {
+			a.Incidents = append(a.Incidents, i)
+		}
+	}
+
+	for _, m := range maintenances {
+		if now.Before(m.End) {
+			a.ScheduledMaintenances = append(a.ScheduledMaintenances, m)
+		}
+	}
+
+	return a
+}
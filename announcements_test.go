@@ -0,0 +1,178 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPage_Announcements(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	path := filepath.Join(t.TempDir(), "announcements.json")
+	file := fmt.Sprintf(`{
+		"incidents": [{
+			"title": "Elevated error rates",
+			"severity": "major",
+			"targets": ["payments"],
+			"updates": [{"time": "2024-01-02T03:04:05Z", "message": "Investigating"}],
+			"resolved_at": %q
+		}, {
+			"title": "Old outage",
+			"severity": "critical",
+			"resolved_at": "2024-01-02T05:00:00Z"
+		}],
+		"scheduled_maintenances": [{
+			"title": "Past upgrade",
+			"start": "2020-01-01T00:00:00Z",
+			"end": "2020-01-01T01:00:00Z"
+		}]
+	}`, now.Add(-time.Hour).Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatalf("failed to write announcements: %v", err)
+	}
+
+	page := NewPage(
+		WithHealthChecker(NewHealthChecker().
+			WithTarget("payments", TargetImportanceHigh, func(ctx context.Context) error { return nil })),
+		WithIncident(Incident{
+			Title:    "Database failover",
+			Severity: IncidentSeverityCritical,
+			Targets:  []string{"postgres", "payments"},
+			Updates: []IncidentUpdate{
+				{Time: now.Add(-time.Hour), Message: "Primary is unreachable"},
+				{Time: now, Message: "Failover in progress"},
+			},
+		}),
+		WithScheduledMaintenance(ScheduledMaintenance{
+			Title:       "PostgreSQL upgrade",
+			Description: "Writes are paused",
+			Start:       now.Add(-time.Minute),
+			End:         now.Add(time.Hour),
+			Targets:     []string{"postgres"},
+		}),
+		WithAnnouncementsFile(path),
+	)
+
+	w := httptest.NewRecorder()
+	page.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	body := w.Body.String()
+
+	for _, expected := range []string{
+		`<div class="announcement incident critical">`,
+		"<h2>Database failover</h2>",
+		"Affected: postgres, payments",
+		"Failover in progress",
+		`<div class="announcement incident major resolved">`,
+		"Maintenance in progress: PostgreSQL upgrade",
+		"Writes are paused",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected page to contain %q", expected)
+		}
+	}
+
+	if strings.Contains(body, "Past upgrade") {
+		t.Error("expected ended maintenance to be left out")
+	}
+
+	if strings.Contains(body, "Old outage") {
+		t.Error("expected incident resolved beyond the retention to be left out")
+	}
+
+	if strings.Index(body, "Database failover") > strings.Index(body, `class="status-section"`) {
+		t.Error("expected announcements above the status grid")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/json")
+
+	w = httptest.NewRecorder()
+	page.Handler().ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected content type application/json, got %s", ct)
+	}
+
+	var data PageData
+	if err := json.NewDecoder(w.Body).Decode(&data); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(data.Incidents) != 2 || data.Incidents[1].Title != "Elevated error rates" || !data.Incidents[1].Resolved() {
+		t.Errorf("unexpected incidents %+v", data.Incidents)
+	}
+
+	if len(data.ScheduledMaintenances) != 1 || data.ScheduledMaintenances[0].Title != "PostgreSQL upgrade" {
+		t.Errorf("unexpected scheduled maintenances %+v", data.ScheduledMaintenances)
+	}
+
+	if len(data.HealthResults) != 1 || data.HealthResults[0].Status != HealthTargetStatusOk {
+		t.Errorf("unexpected results %+v", data.HealthResults)
+	}
+}
+
+func TestPage_AnnouncementsFileError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"incidents": [`), 0o600); err != nil {
+		t.Fatalf("failed to write announcements: %v", err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing.json"), invalid} {
+		page := NewPage(
+			WithIncident(Incident{Title: "Database failover", Severity: IncidentSeverityMajor}),
+			WithAnnouncementsFile(path),
+		)
+
+		w := httptest.NewRecorder()
+		page.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d", filepath.Base(path), http.StatusOK, w.Code)
+		}
+
+		if !strings.Contains(w.Body.String(), "Database failover") {
+			t.Errorf("%s: expected the announcements configured in code to be rendered", filepath.Base(path))
+		}
+	}
+}
+
+func TestPage_ResolvedIncidentRetention(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	page := NewPage(
+		WithResolvedIncidentRetention(time.Hour),
+		WithIncident(Incident{Title: "Recently resolved", ResolvedAt: now.Add(-time.Minute)}),
+		WithIncident(Incident{Title: "Resolved long ago", ResolvedAt: now.Add(-2 * time.Hour)}),
+		WithIncident(Incident{Title: "Ongoing"}),
+	)
+
+	var titles []string
+	for _, incident := range page.currentAnnouncements(now).Incidents {
+		titles = append(titles, incident.Title)
+	}
+
+	if !slices.Equal(titles, []string{"Recently resolved", "Ongoing"}) {
+		t.Errorf("unexpected incidents %v", titles)
+	}
+}
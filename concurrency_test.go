@@ -0,0 +1,294 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var running, peak atomic.Int32
+
+	check := func(ctx context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	checker := NewHealthChecker(WithMaxConcurrency(2))
+	for i := range 6 {
+		checker.WithTarget(fmt.Sprintf("target%d", i), TargetImportanceHigh, check)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Status != HealthTargetStatusOk {
+			t.Errorf("result[%d]: unexpected status %s", i, result.Status)
+		}
+	}
+
+	if p := peak.Load(); p != 2 {
+		t.Errorf("expected at most 2 concurrent checks, got %d", p)
+	}
+}
+
+func TestHealthChecker_MaxConcurrencyWithDependencies(t *testing.T) {
+	t.Parallel()
+
+	check := func(ctx context.Context) error { return nil }
+
+	checker := NewHealthChecker(WithMaxConcurrency(1)).
+		WithTarget("c", TargetImportanceHigh, check, WithDependsOn("b")).
+		WithTarget("b", TargetImportanceHigh, check, WithDependsOn("a")).
+		WithTarget("a", TargetImportanceHigh, check)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Status != HealthTargetStatusOk {
+			t.Errorf("result[%d]: unexpected status %s: %s", i, result.Status, result.ErrorMessage)
+		}
+	}
+}
+
+func TestHealthChecker_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			<-release
+			return nil
+		})
+
+	var wg sync.WaitGroup
+	results := make([][]HealthCheckResult, 5)
+
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var err error
+			results[i], err = checker.Check(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected concurrent checks to share a single run, got %d runs", n)
+	}
+
+	for i, r := range results {
+		if len(r) != 1 || r[0].Status != HealthTargetStatusOk {
+			t.Errorf("caller %d: unexpected results %+v", i, r)
+		}
+	}
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected subsequent check to run again, got %d runs", n)
+	}
+}
+
+func TestHealthChecker_CoalescingCallerCancel(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan []HealthCheckResult, 1)
+	go func() {
+		results, err := checker.Check(ctx)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		first <- results
+	}()
+
+	<-started
+
+	second := make(chan []HealthCheckResult, 1)
+	go func() {
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		second <- results
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	results := <-first
+	if len(results) != 1 || results[0].Status != HealthTargetStatusFail || results[0].ErrorMessage != "context canceled" {
+		t.Errorf("expected the cancelled caller to get a cancelled result, got %+v", results)
+	}
+
+	close(release)
+
+	results = <-second
+	if len(results) != 1 || results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected the waiting caller to get the result of the shared run, got %+v", results)
+	}
+}
+
+func TestHealthChecker_MaxConcurrencyUnlimited(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, -1} {
+		checker := NewHealthChecker(WithMaxConcurrency(n)).
+			WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+		results, err := checker.Check(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if results[0].Status != HealthTargetStatusOk {
+			t.Errorf("WithMaxConcurrency(%d): expected no limit, got %s: %s", n, results[0].Status, results[0].ErrorMessage)
+		}
+	}
+}
+
+func TestHealthChecker_MaxConcurrencyHangingCheck(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	checker := NewHealthChecker(WithMaxConcurrency(1)).
+		WithTarget("hanging", TargetImportanceHigh, func(ctx context.Context) error {
+			<-release
+			return nil
+		}, WithTargetTimeout(10*time.Millisecond)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+	result, err := checker.CheckTarget(context.Background(), "hanging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != HealthTargetStatusFail {
+		t.Errorf("expected the hanging check to time out, got %s", result.Status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := checker.CheckTarget(ctx, "database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("expected the hanging check to keep its slot, got %d checks run meanwhile", n)
+	}
+
+	close(release)
+
+	result, err = checker.CheckTarget(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != HealthTargetStatusOk || calls.Load() != 1 {
+		t.Errorf("expected the slot to be released once the check returns, got %s", result.Status)
+	}
+}
+
+func TestHealthChecker_MaxConcurrencyRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	firstAttempt := make(chan struct{})
+
+	checker := NewHealthChecker(WithMaxConcurrency(1)).
+		WithTarget("flaky", TargetImportanceHigh, func(ctx context.Context) error {
+			if attempts.Add(1) == 1 {
+				close(firstAttempt)
+				return errors.New("connection reset")
+			}
+			return nil
+		}, WithRetry(RetryPolicy{Attempts: 2, Backoff: 200 * time.Millisecond})).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+	flaky := make(chan HealthCheckResult, 1)
+	go func() {
+		result, err := checker.CheckTarget(context.Background(), "flaky")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		flaky <- result
+	}()
+
+	<-firstAttempt
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	result, err := checker.CheckTarget(ctx, "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != HealthTargetStatusOk {
+		t.Errorf("expected the check to run during the retry backoff, got %s: %s", result.Status, result.ErrorMessage)
+	}
+
+	if result := <-flaky; result.Status != HealthTargetStatusOk || result.Attempts != 2 {
+		t.Errorf("expected the retry to succeed, got %s after %d attempts", result.Status, result.Attempts)
+	}
+}
@@ -0,0 +1,73 @@
+package status
+
+// WithFlapDamping makes the target fail only after failAfter consecutive
+// failed checks and recover only after recoverAfter consecutive successful
+// ones. Until then, the target keeps its previous status while the status of
+// the last check is reported as the raw status of the result.
+func WithFlapDamping(failAfter, recoverAfter int) TargetOption {
+	return func(t *HealthTarget) {
+		t.failAfter = failAfter
+		t.recoverAfter = recoverAfter
+	}
+}
+
+// targetState is the state of a target kept between checks.
+type targetState struct {
+	status               HealthTargetStatus
+	consecutiveFailures  int
+	consecutiveSuccesses int
+	notifiedStatus       HealthTargetStatus
+}
+
+// state returns the state of the target with the given name. It must be
+// called with statesMu held.
+func (c *HealthChecker) state(name string) *targetState {
+	if c.states == nil {
+		c.states = make(map[string]*targetState)
+	}
+
+	state, ok := c.states[name]
+	if !ok {
+		state = &targetState{status: HealthTargetStatusOk}
+		c.states[name] = state
+	}
+
+	return state
+}
+
+// damp updates the state of the target of the result and replaces the status
+// of the result with the damped one, if the target has flap damping.
+func (c *HealthChecker) damp(result HealthCheckResult) HealthCheckResult {
+	target := result.Target
+	if target.failAfter <= 1 && target.recoverAfter <= 1 {
+		return result
+	}
+
+	c.statesMu.Lock()
+	defer c.statesMu.Unlock()
+
+	state := c.state(target.Name)
+
+	if result.Status == HealthTargetStatusFail {
+		state.consecutiveFailures++
+		state.consecutiveSuccesses = 0
+
+		if state.consecutiveFailures >= target.failAfter {
+			state.status = HealthTargetStatusFail
+		}
+	} else {
+		state.consecutiveSuccesses++
+		state.consecutiveFailures = 0
+
+		if state.status != HealthTargetStatusFail || state.consecutiveSuccesses >= target.recoverAfter {
+			state.status = result.Status
+		}
+	}
+
+	result.RawStatus = result.Status
+	result.Status = state.status
+	result.ConsecutiveFailures = state.consecutiveFailures
+	result.ConsecutiveSuccesses = state.consecutiveSuccesses
+
+	return result
+}
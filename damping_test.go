@@ -0,0 +1,83 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHealthChecker_FlapDamping(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		}, WithFlapDamping(3, 2))
+
+	steps := []struct {
+		failing              bool
+		expectedStatus       HealthTargetStatus
+		expectedRawStatus    HealthTargetStatus
+		expectedFailures     int
+		expectedSuccesses    int
+		expectedErrorMessage string
+	}{
+		{false, HealthTargetStatusOk, HealthTargetStatusOk, 0, 1, ""},
+		{true, HealthTargetStatusOk, HealthTargetStatusFail, 1, 0, "connection refused"},
+		{false, HealthTargetStatusOk, HealthTargetStatusOk, 0, 1, ""},
+		{true, HealthTargetStatusOk, HealthTargetStatusFail, 1, 0, "connection refused"},
+		{true, HealthTargetStatusOk, HealthTargetStatusFail, 2, 0, "connection refused"},
+		{true, HealthTargetStatusFail, HealthTargetStatusFail, 3, 0, "connection refused"},
+		{false, HealthTargetStatusFail, HealthTargetStatusOk, 0, 1, ""},
+		{false, HealthTargetStatusOk, HealthTargetStatusOk, 0, 2, ""},
+	}
+
+	for i, step := range steps {
+		failing.Store(step.failing)
+
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+
+		result := results[0]
+
+		if result.Status != step.expectedStatus || result.RawStatus != step.expectedRawStatus {
+			t.Errorf("step %d: expected status %s (raw %s), got %s (raw %s)",
+				i, step.expectedStatus, step.expectedRawStatus, result.Status, result.RawStatus)
+		}
+
+		if result.ConsecutiveFailures != step.expectedFailures || result.ConsecutiveSuccesses != step.expectedSuccesses {
+			t.Errorf("step %d: expected %d failures and %d successes, got %d and %d",
+				i, step.expectedFailures, step.expectedSuccesses, result.ConsecutiveFailures, result.ConsecutiveSuccesses)
+		}
+
+		if result.ErrorMessage != step.expectedErrorMessage {
+			t.Errorf("step %d: expected raw error %q, got %q", i, step.expectedErrorMessage, result.ErrorMessage)
+		}
+	}
+}
+
+func TestHealthChecker_NoFlapDamping(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusFail || results[0].RawStatus != "" {
+		t.Errorf("expected undamped failure, got %s (raw %q)", results[0].Status, results[0].RawStatus)
+	}
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithDependsOn declares that the target depends on the targets with the
+// given names. The target is checked after them and skipped when any of them
+// is unhealthy.
+func WithDependsOn(names ...string) TargetOption {
+	return func(t *HealthTarget) {
+		t.DependsOn = append(t.DependsOn, names...)
+	}
+}
+
+// checkCycle returns an error if adding the target, or replacing the one with
+// the same name, would make the dependencies of the targets form a cycle. It
+// must be called with mu held.
+func (c *HealthChecker) checkCycle(target HealthTarget) error {
+	deps := make(map[string][]string, len(c.targets)+1)
+	for _, t := range c.targets {
+		deps[t.Name] = t.DependsOn
+	}
+	deps[target.Name] = target.DependsOn
+
+	visited := make(map[string]bool)
+
+	var visit func(path []string) error
+	visit = func(path []string) error {
+		name := path[len(path)-1]
+
+		for _, dep := range deps[name] {
+			if dep == target.Name {
+				return fmt.Errorf("dependency cycle %s", strings.Join(append(path, dep), " -> "))
+			}
+
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+
+			if err := visit(append(slices.Clone(path), dep)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return visit([]string{target.Name})
+}
+
+// withDependencies returns the targets along with all the registered targets
+// they transitively depend on, each after its dependencies.
+func (c *HealthChecker) withDependencies(targets []HealthTarget) []HealthTarget {
+	registered := c.targetList()
+
+	byName := make(map[string]HealthTarget, len(registered))
+	for _, target := range registered {
+		byName[target.Name] = target
+	}
+
+	ordered := make([]HealthTarget, 0, len(targets))
+	visited := make(map[string]bool, len(targets))
+
+	var visit func(target HealthTarget)
+	visit = func(target HealthTarget) {
+		if visited[target.Name] {
+			return
+		}
+		visited[target.Name] = true
+
+		for _, dep := range target.DependsOn {
+			if t, ok := byName[dep]; ok {
+				visit(t)
+			}
+		}
+
+		ordered = append(ordered, target)
+	}
+
+	for _, target := range targets {
+		visit(target)
+	}
+
+	return ordered
+}
+
+// skipCause returns the name of the unhealthy dependency because of which
+// the target must be skipped, or an empty string if the target must be
+// checked. Unknown dependencies are ignored.
+func skipCause(target HealthTarget, lookup func(name string) (HealthCheckResult, bool)) string {
+	for _, dep := range target.DependsOn {
+		result, ok := lookup(dep)
+		if !ok {
+			continue
+		}
+
+		switch result.Status {
+		case HealthTargetStatusOk, HealthTargetStatusDegraded:
+		case HealthTargetStatusSkipped:
+			return result.SkippedBy
+		default:
+			return dep
+		}
+	}
+
+	return ""
+}
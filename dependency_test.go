@@ -0,0 +1,174 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_Dependencies(t *testing.T) {
+	t.Parallel()
+
+	var primaryDone atomic.Bool
+	var replicaCalls atomic.Int32
+
+	checker := NewHealthChecker().
+		WithTarget("sessions", TargetImportanceHigh, func(ctx context.Context) error {
+			return nil
+		}, WithDependsOn("replica")).
+		WithTarget("replica", TargetImportanceHigh, func(ctx context.Context) error {
+			replicaCalls.Add(1)
+			return nil
+		}, WithDependsOn("primary")).
+		WithTarget("primary", TargetImportanceLow, func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			primaryDone.Store(true)
+			return errors.New("connection refused")
+		}).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error {
+			if !primaryDone.Load() {
+				return errors.New("checked before dependency")
+			}
+			return nil
+		}, WithDependsOn("primary", "unknown"))
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name      string
+		status    HealthTargetStatus
+		skippedBy string
+	}{
+		{"sessions", HealthTargetStatusSkipped, "primary"},
+		{"replica", HealthTargetStatusSkipped, "primary"},
+		{"primary", HealthTargetStatusFail, ""},
+		{"cache", HealthTargetStatusSkipped, "primary"},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+
+	for i, result := range results {
+		if result.Target.Name != expected[i].name {
+			t.Errorf("result[%d]: expected target %s, got %s", i, expected[i].name, result.Target.Name)
+		}
+
+		if result.Status != expected[i].status {
+			t.Errorf("result[%d]: expected status %s, got %s", i, expected[i].status, result.Status)
+		}
+
+		if result.SkippedBy != expected[i].skippedBy {
+			t.Errorf("result[%d]: expected skipped by %q, got %q", i, expected[i].skippedBy, result.SkippedBy)
+		}
+	}
+
+	if calls := replicaCalls.Load(); calls != 0 {
+		t.Errorf("expected skipped target not to be checked, got %d calls", calls)
+	}
+
+	if code := checker.statusCode(results); code != http.StatusOK {
+		t.Errorf("expected skipped high importance targets not to fail the status, got %d", code)
+	}
+}
+
+func TestHealthChecker_DependenciesOrder(t *testing.T) {
+	t.Parallel()
+
+	var primaryDone atomic.Bool
+
+	checker := NewHealthChecker().
+		WithTarget("replica", TargetImportanceHigh, func(ctx context.Context) error {
+			if !primaryDone.Load() {
+				return errors.New("checked before dependency")
+			}
+			return nil
+		}, WithDependsOn("primary"), WithProbes(ProbeLiveness)).
+		WithTarget("primary", TargetImportanceHigh, func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			primaryDone.Store(true)
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	w := httptest.NewRecorder()
+
+	checker.LivenessHandler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"name":"replica"`) || strings.Contains(body, `"name":"primary"`) {
+		t.Errorf("expected only the liveness target in response, got %s", body)
+	}
+}
+
+func TestHealthChecker_DependencyCycle(t *testing.T) {
+	t.Parallel()
+
+	check := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name     string
+		register func(*HealthChecker)
+		expected string
+	}{
+		{
+			name: "self dependency",
+			register: func(c *HealthChecker) {
+				c.WithTarget("a", TargetImportanceHigh, check, WithDependsOn("a"))
+			},
+			expected: "dependency cycle a -> a",
+		},
+		{
+			name: "forward reference cycle",
+			register: func(c *HealthChecker) {
+				c.WithTarget("a", TargetImportanceHigh, check, WithDependsOn("b")).
+					WithTarget("b", TargetImportanceHigh, check, WithDependsOn("c")).
+					WithTarget("c", TargetImportanceHigh, check, WithDependsOn("a"))
+			},
+			expected: "dependency cycle c -> a -> b -> c",
+		},
+		{
+			name: "diamond is not a cycle",
+			register: func(c *HealthChecker) {
+				c.WithTarget("a", TargetImportanceHigh, check, WithDependsOn("b", "c")).
+					WithTarget("b", TargetImportanceHigh, check, WithDependsOn("d")).
+					WithTarget("c", TargetImportanceHigh, check, WithDependsOn("d")).
+					WithTarget("d", TargetImportanceHigh, check)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+
+				if tt.expected == "" {
+					if r != nil {
+						t.Errorf("unexpected panic: %v", r)
+					}
+					return
+				}
+
+				msg, _ := r.(string)
+				if !strings.Contains(msg, tt.expected) {
+					t.Errorf("expected panic with %q, got %v", tt.expected, r)
+				}
+			}()
+
+			tt.register(NewHealthChecker())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package status
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// detailsKey is the context key of the details recorder of a check.
+type detailsKey struct{}
+
+// detailsRecorder collects the details reported by a check.
+type detailsRecorder struct {
+	mu      sync.Mutex
+	details map[string]any
+}
+
+// SetDetail reports a detail of the check running with ctx, e.g. the
+// replication lag, to be included in its HealthCheckResult. It does nothing
+// outside of a check.
+func SetDetail(ctx context.Context, key string, value any) {
+	d, ok := ctx.Value(detailsKey{}).(*detailsRecorder)
+	if !ok {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.details == nil {
+		d.details = make(map[string]any)
+	}
+	d.details[key] = value
+}
+
+// snapshot returns a copy of the details reported so far.
+func (d *detailsRecorder) snapshot() map[string]any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return maps.Clone(d.details)
+}
@@ -6,12 +6,20 @@ package status
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 // HealthTarget represents a single health check target with its name,
@@ -19,7 +27,67 @@ import (
 type HealthTarget struct {
 	Name       string           `json:"name"`
 	Importance TargetImportance `json:"importance"`
+	Group      string           `json:"group,omitempty"`
+	Tags       []string         `json:"tags,omitempty"`
+	DependsOn  []string         `json:"depends_on,omitempty"`
 	check      HealthCheckFunc
+	report     DetailedHealthCheckFunc
+	interval   time.Duration
+	timeout    time.Duration
+	probes     []ProbeKind
+
+	latencyWarn time.Duration
+	latencyFail time.Duration
+
+	failAfter    int
+	recoverAfter int
+
+	retry RetryPolicy
+}
+
+// TargetOption is a function that configures a HealthTarget.
+type TargetOption func(*HealthTarget)
+
+// WithTargetInterval sets how often the target is checked in the background
+// once the checker is started. It overrides the checker-wide interval unless
+// it is not positive.
+func WithTargetInterval(interval time.Duration) TargetOption {
+	return func(t *HealthTarget) {
+		t.interval = interval
+	}
+}
+
+// WithGroup puts the target into the named group, e.g. "Databases".
+func WithGroup(name string) TargetOption {
+	return func(t *HealthTarget) {
+		t.Group = name
+	}
+}
+
+// WithTags tags the target, e.g. "storage" or "external", so requests can
+// be restricted to the targets with a tag.
+func WithTags(tags ...string) TargetOption {
+	return func(t *HealthTarget) {
+		t.Tags = append(t.Tags, tags...)
+	}
+}
+
+// WithTargetTimeout limits how long a single check of the target may take.
+// It overrides the checker-wide default timeout.
+func WithTargetTimeout(timeout time.Duration) TargetOption {
+	return func(t *HealthTarget) {
+		t.timeout = timeout
+	}
+}
+
+// WithLatencyThresholds reports the target degraded when a successful check
+// takes longer than warn and failed when it takes longer than fail. Zero
+// disables the corresponding threshold.
+func WithLatencyThresholds(warn, fail time.Duration) TargetOption {
+	return func(t *HealthTarget) {
+		t.latencyWarn = warn
+		t.latencyFail = fail
+	}
 }
 
 // TargetImportance defines the importance level of a health check target.
@@ -35,27 +103,220 @@ const (
 // HealthCheckFunc is a function type that performs a health check and returns an error if unhealthy.
 type HealthCheckFunc func(ctx context.Context) error
 
+// DetailedHealthCheckFunc is a function type that performs a health check and
+// reports what it observed, returning an error if unhealthy.
+type DetailedHealthCheckFunc func(ctx context.Context) (CheckReport, error)
+
+// CheckReport describes what a DetailedHealthCheckFunc observed, e.g.
+// replication lag of 340ms.
+type CheckReport struct {
+	// Details holds arbitrary key/value details of the check.
+	Details map[string]any
+	// ObservedValue is the value observed by the check.
+	ObservedValue any
+	// ObservedUnit is the unit of ObservedValue, e.g. "ms" or "percent".
+	ObservedUnit string
+	// Measurement names ObservedValue, e.g. "replicationLag". Defaults to
+	// "value".
+	Measurement string
+	// Output is a human readable message about the check.
+	Output string
+}
+
+// degradedError marks the error of a degraded target.
+type degradedError struct {
+	err error
+}
+
+// Degraded wraps the error returned by a check to report the target as
+// degraded instead of failed. Degraded(nil) returns nil, reporting the
+// target as healthy.
+func Degraded(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &degradedError{err: err}
+}
+
+func (e *degradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *degradedError) Unwrap() error {
+	return e.err
+}
+
+// ErrCheckerStarted is returned by Start when the background checks are
+// already running.
+var ErrCheckerStarted = errors.New("health checker is already started")
+
+// defaultCheckInterval is the background check interval used when neither the
+// checker nor the target configures one.
+const defaultCheckInterval = 30 * time.Second
+
+// defaultRunTimeout limits how long a shared run of the checks may take when
+// the checker doesn't configure it.
+const defaultRunTimeout = time.Minute
+
 // HealthChecker manages a collection of health check targets and provides
 // functionality to check their health status.
 type HealthChecker struct {
-	targets []HealthTarget
+	targets      []HealthTarget
+	interval     time.Duration
+	timeout      time.Duration
+	runTimeout   time.Duration
+	format       ResponseFormat
+	degradedCode int
+	version      string
+	releaseID    string
+
+	mu        sync.RWMutex
+	cached    map[string]HealthCheckResult
+	cancel    context.CancelFunc
+	runCtx    context.Context
+	runners   map[string]context.CancelFunc
+	stopped   chan struct{}
+	wg        sync.WaitGroup
+	readiness *bool
+	startedUp bool
+	metrics   *metrics
+
+	disabled           map[string]bool
+	muted              map[string]bool
+	maintenance        *Maintenance
+	targetMaintenances map[string]Maintenance
+
+	history         HistoryStore
+	historyWindow   time.Duration
+	historyPrunedAt atomic.Int64
+
+	statesMu sync.Mutex
+	states   map[string]*targetState
+
+	flight singleflight.Group
+	sem    chan struct{}
+
+	panicHandler PanicHandler
+	notifiers    []*notifierQueue
+}
+
+// HealthCheckerOption is a function that configures a HealthChecker.
+type HealthCheckerOption func(*HealthChecker)
+
+// WithCheckInterval sets the default background check interval for targets
+// that don't configure their own. Non-positive intervals are ignored.
+func WithCheckInterval(interval time.Duration) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithDefaultTimeout sets the timeout applied to checks of targets that don't
+// configure their own. Zero means no timeout.
+func WithDefaultTimeout(timeout time.Duration) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.timeout = timeout
+	}
+}
+
+// WithRunTimeout limits how long a run of the checks shared by concurrent
+// callers may take. The run doesn't end when the callers waiting for it give
+// up, so the timeout keeps a hanging check from running forever. Defaults to
+// 1 minute, zero means no timeout.
+func WithRunTimeout(timeout time.Duration) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.runTimeout = timeout
+	}
+}
+
+// WithDegradedStatusCode sets the HTTP status code responded when a high
+// importance target is degraded and none is failed. Defaults to 200.
+func WithDegradedStatusCode(code int) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.degradedCode = code
+	}
+}
+
+// WithMaxConcurrency limits the number of checks running at the same time,
+// across all the runs of the checker, to n. A check that times out but
+// ignores its context still counts until it returns, while a check waiting
+// to be retried doesn't. Zero or less means no limit.
+func WithMaxConcurrency(n int) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		if n <= 0 {
+			c.sem = nil
+			return
+		}
+		c.sem = make(chan struct{}, n)
+	}
 }
 
 // NewHealthChecker creates a new HealthChecker instance.
-func NewHealthChecker() *HealthChecker {
-	return &HealthChecker{}
+func NewHealthChecker(opts ...HealthCheckerOption) *HealthChecker {
+	c := &HealthChecker{
+		interval:     defaultCheckInterval,
+		runTimeout:   defaultRunTimeout,
+		degradedCode: http.StatusOK,
+		metrics:      newMetrics(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-// WithTarget adds a new health check target to the checker.
-func (c *HealthChecker) WithTarget(name string, importance TargetImportance, check HealthCheckFunc) *HealthChecker {
-	c.targets = append(c.targets, HealthTarget{
+// WithTarget adds a new health check target to the checker. A target with
+// the same name registered before is replaced, with a warning logged. It
+// panics if the dependencies of the target form a cycle.
+func (c *HealthChecker) WithTarget(
+	name string, importance TargetImportance, check HealthCheckFunc, opts ...TargetOption,
+) *HealthChecker {
+	return c.withTarget(HealthTarget{
 		Name:       name,
 		Importance: importance,
 		check:      check,
-	})
+	}, opts)
+}
+
+// WithDetailedTarget adds a new health check target reporting details of its
+// checks to the checker. A target with the same name registered before is
+// replaced, with a warning logged. It panics if the dependencies of the
+// target form a cycle.
+func (c *HealthChecker) WithDetailedTarget(
+	name string, importance TargetImportance, check DetailedHealthCheckFunc, opts ...TargetOption,
+) *HealthChecker {
+	return c.withTarget(HealthTarget{
+		Name:       name,
+		Importance: importance,
+		report:     check,
+	}, opts)
+}
+
+// withTarget adds the target to the checker or replaces the one with the same
+// name, panicking on error.
+func (c *HealthChecker) withTarget(target HealthTarget, opts []TargetOption) *HealthChecker {
+	err := c.addTarget(target, opts)
+	if errors.Is(err, ErrTargetExists) {
+		log.Printf("status: target %s is registered twice, replacing the earlier one", target.Name)
+		err = c.replaceTarget(target, opts)
+	}
+
+	if err != nil {
+		panic("status: " + err.Error())
+	}
+
 	return c
 }
 
+// Handler returns an HTTP handler responding with the results of the
+// targets. The request may be restricted with the query parameters target,
+// importance, group and tag, each accepting multiple values, and
+// verbose=false responds with the overall status only.
 func (c *HealthChecker) Handler() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if _, noDeps := r.URL.Query()["no_deps"]; noDeps {
@@ -63,75 +324,276 @@ func (c *HealthChecker) Handler() http.HandlerFunc {
 			return
 		}
 
+		query, err := parseResultsQuery(r.URL.Query())
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		if name, ok := c.unknownTarget(query.targets); ok {
+			respondJSON(w, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("unknown target %q", name)})
+			return
+		}
+
 		ctx := r.Context()
 
-		results, err := c.Check(ctx)
+		results, err := c.results(ctx, query.match)
 		if err != nil {
 			respondJSON(w, http.StatusInternalServerError, err)
 			return
 		}
 
-		status := http.StatusOK
-
-		for _, result := range results {
-			if result.Target.Importance == TargetImportanceHigh &&
-				(result.Status != HealthTargetStatusOk || result.err != nil) {
-				status = http.StatusInternalServerError
-				break
-			}
+		if !query.verbose {
+			c.respondStatus(w, r, results)
+			return
 		}
 
-		respondJSON(w, status, results)
+		c.respondResults(w, r, results)
 	})
 }
 
+// respondResults responds with the results in the format configured for the
+// checker or requested by the client, and returns the status code written.
+func (c *HealthChecker) respondResults(w http.ResponseWriter, r *http.Request, results []HealthCheckResult) int {
+	code := c.statusCode(results)
+
+	if c.format == ResponseFormatHealthJSON || acceptsHealthJSON(r) {
+		respond(w, code, healthJSONContentType, c.healthResponse(results))
+		return code
+	}
+
+	respondJSON(w, code, results)
+
+	return code
+}
+
+// respondStatus responds with the overall status of the results only, in the
+// format configured for the checker or requested by the client, and returns
+// the status code written.
+func (c *HealthChecker) respondStatus(w http.ResponseWriter, r *http.Request, results []HealthCheckResult) int {
+	code := c.statusCode(results)
+
+	if c.format == ResponseFormatHealthJSON || acceptsHealthJSON(r) {
+		resp := c.healthResponse(results)
+		resp.Checks = nil
+		respond(w, code, healthJSONContentType, resp)
+		return code
+	}
+
+	respondJSON(w, code, statusResponse{Status: overallStatus(results)})
+
+	return code
+}
+
+// statusResponse is the body of the responses with the overall status only.
+type statusResponse struct {
+	Status HealthTargetStatus `json:"status"`
+}
+
+// statusCode returns the HTTP status code describing the overall health of
+// the results.
+func (c *HealthChecker) statusCode(results []HealthCheckResult) int {
+	switch overallStatus(results) {
+	case HealthTargetStatusOk:
+		return http.StatusOK
+	case HealthTargetStatusDegraded:
+		return c.degradedCode
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// overallStatus returns the overall status of the results: any failed high
+// importance target makes it fail and any degraded one makes it degraded.
+// Skipped targets are left to the dependencies that caused them to be
+// skipped and targets under maintenance are ignored.
+func overallStatus(results []HealthCheckResult) HealthTargetStatus {
+	status := HealthTargetStatusOk
+
+	for _, result := range results {
+		if result.Target.Importance != TargetImportanceHigh {
+			continue
+		}
+
+		switch result.Status {
+		case HealthTargetStatusOk, HealthTargetStatusSkipped, HealthTargetStatusMaintenance:
+		case HealthTargetStatusDegraded:
+			status = HealthTargetStatusDegraded
+		default:
+			return HealthTargetStatusFail
+		}
+	}
+
+	return status
+}
+
 // HealthTargetStatus represents the status of a health check target.
 type HealthTargetStatus string
 
 const (
 	// HealthTargetStatusOk indicates that the target is healthy.
 	HealthTargetStatusOk = HealthTargetStatus("ok")
+	// HealthTargetStatusDegraded indicates that the target works but
+	// not as expected, e.g. responds slowly.
+	HealthTargetStatusDegraded = HealthTargetStatus("degraded")
 	// HealthTargetStatusFail indicates that the target is unhealthy.
 	HealthTargetStatusFail = HealthTargetStatus("fail")
+	// HealthTargetStatusSkipped indicates that the target wasn't checked
+	// because one of its dependencies is unhealthy.
+	HealthTargetStatusSkipped = HealthTargetStatus("skipped")
+	// HealthTargetStatusMaintenance indicates that the target is under
+	// planned maintenance.
+	HealthTargetStatusMaintenance = HealthTargetStatus("maintenance")
 )
 
 // HealthCheckResult contains the result of a health check for a target.
 type HealthCheckResult struct {
-	Target       HealthTarget       `json:"target"`
-	Status       HealthTargetStatus `json:"status"`
-	ErrorMessage string             `json:"error,omitempty"`
-	Duration     time.Duration      `json:"duration,omitempty"`
-	err          error
+	Target        HealthTarget       `json:"target"`
+	Status        HealthTargetStatus `json:"status"`
+	ErrorMessage  string             `json:"error,omitempty"`
+	Duration      time.Duration      `json:"duration,omitempty"`
+	Timeout       time.Duration      `json:"timeout,omitempty"`
+	LatencyWarn   time.Duration      `json:"latency_warn,omitempty"`
+	LatencyFail   time.Duration      `json:"latency_fail,omitempty"`
+	CheckedAt     time.Time          `json:"checked_at"`
+	Attempts      int                `json:"attempts,omitempty"`
+	SkippedBy     string             `json:"skipped_by,omitempty"`
+	Maintenance   *Maintenance       `json:"maintenance,omitempty"`
+	Details       map[string]any     `json:"details,omitempty"`
+	ObservedValue any                `json:"observed_value,omitempty"`
+	ObservedUnit  string             `json:"observed_unit,omitempty"`
+	Measurement   string             `json:"measurement,omitempty"`
+	Output        string             `json:"output,omitempty"`
+
+	RawStatus            HealthTargetStatus `json:"raw_status,omitempty"`
+	ConsecutiveFailures  int                `json:"consecutive_failures,omitempty"`
+	ConsecutiveSuccesses int                `json:"consecutive_successes,omitempty"`
+
+	err error
 }
 
 // Check performs health checks for all registered targets concurrently.
 func (c *HealthChecker) Check(ctx context.Context) ([]HealthCheckResult, error) {
-	results := make([]HealthCheckResult, len(c.targets))
+	return c.check(ctx, c.targetList())
+}
 
-	g, ctx := errgroup.WithContext(ctx)
+// CheckTarget immediately checks the target with the given name, along with
+// its dependencies. When the checker is started, the cached result of the
+// target is updated as well, unless ctx is done before the check completes.
+func (c *HealthChecker) CheckTarget(ctx context.Context, name string) (HealthCheckResult, error) {
+	c.mu.RLock()
+	i := c.targetIndex(name)
+	if i < 0 || c.disabled[name] {
+		c.mu.RUnlock()
+		return HealthCheckResult{}, fmt.Errorf("checking target %s: %w", name, ErrTargetNotFound)
+	}
+	target := c.targets[i]
+	c.mu.RUnlock()
 
-	for i, target := range c.targets {
-		g.Go(func() error {
-			start := time.Now()
-			err := target.check(ctx)
-			duration := time.Since(start)
-
-			if err != nil {
-				results[i] = HealthCheckResult{
-					Target:       target,
-					Status:       HealthTargetStatusFail,
-					err:          err,
-					ErrorMessage: err.Error(),
-					Duration:     duration,
-				}
-			} else {
-				results[i] = HealthCheckResult{
-					Target:   target,
-					Status:   HealthTargetStatusOk,
-					Duration: duration,
-				}
+	results, err := c.runChecks(ctx, []HealthTarget{target})
+	if err != nil {
+		return HealthCheckResult{}, err
+	}
+	result := results[0]
+
+	c.mu.Lock()
+	if _, ok := c.runners[name]; ok && ctx.Err() == nil {
+		c.cached[name] = result
+	}
+	c.mu.Unlock()
+
+	return c.applyMaintenance(result), nil
+}
+
+// check performs health checks for the given targets like shared and
+// reports the targets under maintenance as such.
+func (c *HealthChecker) check(ctx context.Context, targets []HealthTarget) ([]HealthCheckResult, error) {
+	results, err := c.shared(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i] = c.applyMaintenance(results[i])
+	}
+
+	return results, nil
+}
+
+// shared performs health checks for the given targets. Concurrent calls for
+// the same targets share a single run, which isn't cancelled when its callers
+// give up but is limited by the run timeout of the checker. A caller whose
+// ctx is done before the run completes gets the targets failed with the error
+// of ctx.
+func (c *HealthChecker) shared(ctx context.Context, targets []HealthTarget) ([]HealthCheckResult, error) {
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.Name
+	}
+
+	ch := c.flight.DoChan(strings.Join(names, "\x00"), func() (any, error) {
+		runCtx := context.WithoutCancel(ctx)
+		if c.runTimeout > 0 {
+			var cancel context.CancelFunc
+			runCtx, cancel = context.WithTimeout(runCtx, c.runTimeout)
+			defer cancel()
+		}
+
+		return c.runChecks(runCtx, targets)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return slices.Clone(res.Val.([]HealthCheckResult)), nil
+	case <-ctx.Done():
+		results := make([]HealthCheckResult, len(targets))
+		for i, target := range targets {
+			results[i] = HealthCheckResult{
+				Target:       target,
+				Status:       HealthTargetStatusFail,
+				ErrorMessage: ctx.Err().Error(),
+				CheckedAt:    time.Now(),
+				err:          ctx.Err(),
 			}
+		}
+		return results, nil
+	}
+}
+
+// runChecks performs health checks for the given targets and their
+// dependencies concurrently. A target is checked only after all of its
+// dependencies are.
+func (c *HealthChecker) runChecks(ctx context.Context, targets []HealthTarget) ([]HealthCheckResult, error) {
+	ordered := c.withDependencies(targets)
+
+	results := make([]HealthCheckResult, len(ordered))
+	indices := make(map[string]int, len(ordered))
+	done := make([]chan struct{}, len(ordered))
+
+	for i, target := range ordered {
+		indices[target.Name] = i
+		done[i] = make(chan struct{})
+	}
+
+	// lookup returns the result of a dependency once it is checked.
+	lookup := func(name string) (HealthCheckResult, bool) {
+		i, ok := indices[name]
+		if !ok {
+			return HealthCheckResult{}, false
+		}
+		<-done[i]
+		return results[i], true
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
 
+	for i, target := range ordered {
+		g.Go(func() error {
+			defer close(done[i])
+			results[i] = c.checkTarget(ctx, target, lookup)
 			return nil
 		})
 	}
@@ -140,13 +602,420 @@ func (c *HealthChecker) Check(ctx context.Context) ([]HealthCheckResult, error)
 		return nil, fmt.Errorf("waiting errgroup: %w", err)
 	}
 
+	requested := make([]HealthCheckResult, len(targets))
+	for i, target := range targets {
+		requested[i] = results[indices[target.Name]]
+	}
+
+	return requested, nil
+}
+
+// Results returns the latest cached results while the checker is started
+// and performs Check otherwise.
+func (c *HealthChecker) Results(ctx context.Context) ([]HealthCheckResult, error) {
+	return c.results(ctx, nil)
+}
+
+// results returns the results of the targets matching filter, taking them
+// from the cache while the checker is started. A nil filter matches every
+// target.
+func (c *HealthChecker) results(ctx context.Context, filter func(HealthTarget) bool) ([]HealthCheckResult, error) {
+	c.mu.RLock()
+	targets := make([]HealthTarget, 0, len(c.targets))
+	for _, target := range c.targets {
+		if !c.disabled[target.Name] && (filter == nil || filter(target)) {
+			targets = append(targets, target)
+		}
+	}
+
+	if c.cached == nil {
+		c.mu.RUnlock()
+		return c.check(ctx, targets)
+	}
+
+	// Targets added after Start are left out until their first check.
+	results := make([]HealthCheckResult, 0, len(targets))
+	for _, target := range targets {
+		if result, ok := c.cached[target.Name]; ok {
+			results = append(results, result)
+		}
+	}
+	c.mu.RUnlock()
+
+	for i := range results {
+		results[i] = c.applyMaintenance(results[i])
+	}
+
 	return results, nil
 }
 
+// Start checks every target once and then keeps checking each of them in the
+// background on its own interval until ctx is done or Stop is called, either
+// of which stops the checker. While the checker is started, Results serves
+// the cached results instead of running the checks on every call. Targets
+// added while the checker is started are checked in the background as well.
+func (c *HealthChecker) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.mu.Unlock()
+		return ErrCheckerStarted
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	results, err := c.shared(ctx, c.targetList())
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		cancel()
+		c.mu.Lock()
+		c.cancel = nil
+		c.mu.Unlock()
+		return fmt.Errorf("checking targets: %w", err)
+	}
+
+	cached := make(map[string]HealthCheckResult, len(results))
+	for _, result := range results {
+		cached[result.Target.Name] = result
+	}
+
+	stopped := make(chan struct{})
+
+	c.mu.Lock()
+	c.cached = cached
+	c.runCtx = ctx
+	c.stopped = stopped
+	c.runners = make(map[string]context.CancelFunc, len(c.targets))
+	for _, target := range c.targets {
+		if !c.disabled[target.Name] {
+			c.startRunner(target, false)
+		}
+	}
+	c.mu.Unlock()
+
+	go c.watch(ctx, stopped)
+
+	return nil
+}
+
+// Stop stops the background checks and waits for them to return and for the
+// pending notifications to be delivered. Once stopped, Results performs the
+// checks on every call again.
+func (c *HealthChecker) Stop() {
+	// Cancelling with mu held keeps startRunner from adding to wg once it is
+	// waited for.
+	c.mu.Lock()
+	cancel, stopped := c.cancel, c.stopped
+	if cancel != nil {
+		cancel()
+	}
+	c.mu.Unlock()
+
+	if stopped != nil {
+		<-stopped
+	}
+
+	c.waitNotifications()
+}
+
+// watch stops the checker once ctx is done, whether by Stop or by the
+// context passed to Start: it waits for the background checks to return,
+// clears the started state and closes stopped.
+func (c *HealthChecker) watch(ctx context.Context, stopped chan struct{}) {
+	<-ctx.Done()
+	c.wg.Wait()
+
+	c.mu.Lock()
+	c.cancel()
+	c.cancel = nil
+	c.cached = nil
+	c.runCtx = nil
+	c.runners = nil
+	c.stopped = nil
+	c.mu.Unlock()
+
+	close(stopped)
+}
+
+// startRunner starts checking the target in the background unless the
+// checker is stopping. It must be called with mu held while the checker is
+// started.
+func (c *HealthChecker) startRunner(target HealthTarget, immediate bool) {
+	if c.runCtx.Err() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.runCtx)
+	c.runners[target.Name] = cancel
+
+	c.wg.Add(1)
+	go c.run(ctx, target, immediate)
+}
+
+// stopRunner stops checking the target with the given name in the background
+// and forgets its cached result. It must be called with mu held.
+func (c *HealthChecker) stopRunner(name string) {
+	if cancel, ok := c.runners[name]; ok {
+		cancel()
+		delete(c.runners, name)
+	}
+
+	delete(c.cached, name)
+}
+
+// run periodically checks a target and caches its result. If immediate is
+// set, the target is checked right away instead of after the first interval.
+func (c *HealthChecker) run(ctx context.Context, target HealthTarget, immediate bool) {
+	defer c.wg.Done()
+
+	interval := target.interval
+	if interval <= 0 {
+		interval = c.interval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	check := func() {
+		result := c.checkTarget(ctx, target, c.cachedResult)
+
+		c.mu.Lock()
+		// The target may have been removed or replaced during the check.
+		if ctx.Err() == nil {
+			c.cached[target.Name] = result
+		}
+		c.mu.Unlock()
+	}
+
+	if immediate {
+		check()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// cachedResult returns the cached result of the target with the given name.
+func (c *HealthChecker) cachedResult(name string) (HealthCheckResult, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result, ok := c.cached[name]
+
+	return result, ok
+}
+
+// checkTarget checks a single target, unless lookup reports one of its
+// dependencies unhealthy, and records the result unless ctx is done. Targets
+// under maintenance are still checked, but recorded as under maintenance.
+func (c *HealthChecker) checkTarget(
+	ctx context.Context, target HealthTarget, lookup func(name string) (HealthCheckResult, bool),
+) HealthCheckResult {
+	var result HealthCheckResult
+	if cause := skipCause(target, lookup); cause != "" {
+		result = HealthCheckResult{
+			Target:    target,
+			Status:    HealthTargetStatusSkipped,
+			CheckedAt: time.Now(),
+			SkippedBy: cause,
+		}
+	} else if release, err := c.acquire(ctx); err != nil {
+		result = HealthCheckResult{
+			Target:       target,
+			Status:       HealthTargetStatusFail,
+			ErrorMessage: err.Error(),
+			CheckedAt:    time.Now(),
+			err:          err,
+		}
+	} else {
+		result = c.runTarget(ctx, target, release)
+
+		var panicErr *panicError
+		if errors.As(result.err, &panicErr) {
+			c.onPanic(target.Name, panicErr.value, panicErr.stack)
+		}
+	}
+
+	// A check aborted by ctx, e.g. on Stop, on removal of the target or on
+	// the run timeout, says nothing about the target, so it isn't recorded,
+	// and neither is a check of a target removed or disabled meanwhile.
+	// Holding mu keeps RemoveTarget and DisableTarget from forgetting the
+	// target in between.
+	c.mu.RLock()
+	if ctx.Err() != nil || c.targetIndex(target.Name) < 0 || c.disabled[target.Name] {
+		c.mu.RUnlock()
+		return result
+	}
+
+	if result.Status != HealthTargetStatusSkipped {
+		result = c.damp(result)
+	}
+
+	c.metrics.observe(result)
+	recorded := c.maintained(result)
+	c.notify(ctx, recorded)
+	c.mu.RUnlock()
+
+	c.appendHistory(ctx, recorded)
+
+	return result
+}
+
+// acquire waits for a free slot to run a check when the concurrency of the
+// checker is limited. The returned function releases the slot and must be
+// called exactly once.
+func (c *HealthChecker) acquire(ctx context.Context) (func(), error) {
+	if c.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		return func() { <-c.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runTarget runs the check of a single target within its timeout and
+// measures its duration. The check runs in the concurrency slot freed by
+// release.
+func (c *HealthChecker) runTarget(ctx context.Context, target HealthTarget, release func()) HealthCheckResult {
+	checkCtx := ctx
+	if target.timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, target.timeout)
+		defer cancel()
+	}
+
+	details := &detailsRecorder{}
+	checkCtx = context.WithValue(checkCtx, detailsKey{}, details)
+
+	start := time.Now()
+	report, attempts, err := c.runAttempts(checkCtx, target, release)
+
+	result := HealthCheckResult{
+		Target:        target,
+		Status:        HealthTargetStatusOk,
+		Duration:      time.Since(start),
+		Timeout:       target.timeout,
+		LatencyWarn:   target.latencyWarn,
+		LatencyFail:   target.latencyFail,
+		CheckedAt:     start,
+		Attempts:      attempts,
+		Details:       details.snapshot(),
+		ObservedValue: report.ObservedValue,
+		ObservedUnit:  report.ObservedUnit,
+		Measurement:   report.Measurement,
+		Output:        report.Output,
+	}
+
+	if result.ObservedValue != nil && result.Measurement == "" {
+		result.Measurement = "value"
+	}
+
+	var panicErr *panicError
+	if errors.As(err, &panicErr) {
+		report.Details = map[string]any{"panic_stack": panicErr.truncatedStack()}
+	}
+
+	if len(report.Details) > 0 {
+		if result.Details == nil {
+			result.Details = make(map[string]any, len(report.Details))
+		}
+		maps.Copy(result.Details, report.Details)
+	}
+
+	if err != nil && checkCtx.Err() != nil && ctx.Err() == nil {
+		err = fmt.Errorf("timed out after %s: %w", target.timeout, context.DeadlineExceeded)
+	}
+
+	if err == nil || errors.As(err, new(*degradedError)) {
+		err = checkLatency(result.Duration, target, err)
+	}
+
+	if err != nil {
+		result.Status = HealthTargetStatusFail
+		result.err = err
+		result.ErrorMessage = err.Error()
+
+		var degraded *degradedError
+		if errors.As(err, &degraded) {
+			result.Status = HealthTargetStatusDegraded
+		}
+	}
+
+	return result
+}
+
+// checkLatency returns the error of a check that took duration according to
+// the latency thresholds of the target. err is the error returned by a
+// successful or degraded check.
+func checkLatency(duration time.Duration, target HealthTarget, err error) error {
+	switch {
+	case target.latencyFail > 0 && duration > target.latencyFail:
+		return fmt.Errorf("latency %s exceeds fail threshold %s", duration, target.latencyFail)
+	case err == nil && target.latencyWarn > 0 && duration > target.latencyWarn:
+		return Degraded(fmt.Errorf("latency %s exceeds warn threshold %s", duration, target.latencyWarn))
+	default:
+		return err
+	}
+}
+
+// runCheck calls the check of the target and returns as soon as either it
+// returns or ctx is done, so a check ignoring its context can't block the
+// caller. release is called once the check actually returns, so a check
+// ignoring its context keeps its concurrency slot until then.
+func runCheck(ctx context.Context, target HealthTarget, release func()) (CheckReport, error) {
+	type outcome struct {
+		report CheckReport
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+
+	go func() {
+		defer release()
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: &panicError{value: r, stack: debug.Stack()}}
+			}
+		}()
+
+		if target.report != nil {
+			report, err := target.report(ctx)
+			done <- outcome{report: report, err: err}
+			return
+		}
+
+		done <- outcome{err: target.check(ctx)}
+	}()
+
+	select {
+	case o := <-done:
+		return o.report, o.err
+	case <-ctx.Done():
+		return CheckReport{}, ctx.Err()
+	}
+}
+
 // respondJSON responds JSON body with a given code. It sets
 // Content-Type header.
 func respondJSON(w http.ResponseWriter, code int, data any) {
-	w.Header().Set("Content-Type", "application/json")
+	respond(w, code, "application/json", data)
+}
+
+// respond responds JSON body with a given code and Content-Type header.
+func respond(w http.ResponseWriter, code int, contentType string, data any) {
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(&data); err != nil {
 		log.Printf("encoding data to respond with json: %v", err)
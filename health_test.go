@@ -6,6 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -114,6 +116,32 @@ func TestHealthChecker_Handler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "target group is exposed",
+			targets: []HealthTarget{
+				{
+					Name:       "test1",
+					Importance: TargetImportanceHigh,
+					Group:      "Databases",
+					check: func(ctx context.Context) error {
+						return nil
+					},
+				},
+			},
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"target": map[string]interface{}{
+						"name":       "test1",
+						"importance": "high",
+						"group":      "Databases",
+					},
+					"status":   "ok",
+					"duration": float64(0),
+				},
+			},
+		},
 		{
 			name: "high importance target failure returns 500",
 			targets: []HealthTarget{
@@ -161,7 +189,7 @@ func TestHealthChecker_Handler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			checker := NewHealthChecker()
 			for _, target := range tt.targets {
-				checker.WithTarget(target.Name, target.Importance, target.check)
+				checker.WithTarget(target.Name, target.Importance, target.check, WithGroup(target.Group))
 			}
 
 			req := httptest.NewRequest(http.MethodGet, "/health"+tt.queryParams, nil)
@@ -200,6 +228,9 @@ func TestHealthChecker_Handler(t *testing.T) {
 				if target["importance"] != expectedTarget["importance"] {
 					t.Errorf("result[%d]: expected target importance %s, got %s", i, expectedTarget["importance"], target["importance"])
 				}
+				if target["group"] != expectedTarget["group"] {
+					t.Errorf("result[%d]: expected target group %v, got %v", i, expectedTarget["group"], target["group"])
+				}
 
 				// Compare status
 				if result["status"] != expectedResult["status"] {
@@ -361,3 +392,423 @@ func TestHealthChecker_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthChecker_StartContextDone(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := NewHealthChecker(WithCheckInterval(time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := checker.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := checker.Start(context.Background())
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrCheckerStarted) || time.Now().After(deadline) {
+			t.Fatalf("expected the checker to stop once ctx is done, got %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	defer checker.Stop()
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected restarting to check again, got %d calls", n)
+	}
+}
+
+func TestHealthChecker_StartStop(t *testing.T) {
+	t.Parallel()
+
+	var hourlyCalls, frequentCalls atomic.Int32
+
+	checker := NewHealthChecker(WithCheckInterval(time.Hour)).
+		WithTarget("hourly", TargetImportanceHigh, func(ctx context.Context) error {
+			hourlyCalls.Add(1)
+			return nil
+		}).
+		WithTarget("frequent", TargetImportanceLow, func(ctx context.Context) error {
+			frequentCalls.Add(1)
+			return errors.New("frequent error")
+		}, WithTargetInterval(10*time.Millisecond))
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer checker.Stop()
+
+	if err := checker.Start(context.Background()); !errors.Is(err, ErrCheckerStarted) {
+		t.Errorf("expected %v on second start, got %v", ErrCheckerStarted, err)
+	}
+
+	first, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(first) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(first))
+	}
+
+	if first[0].Status != HealthTargetStatusOk || first[1].Status != HealthTargetStatusFail {
+		t.Errorf("unexpected statuses %s, %s", first[0].Status, first[1].Status)
+	}
+
+	if first[0].CheckedAt.IsZero() || first[1].CheckedAt.IsZero() {
+		t.Error("expected results to have checked_at set")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	second, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !second[0].CheckedAt.Equal(first[0].CheckedAt) {
+		t.Error("expected hourly target result to be served from cache")
+	}
+
+	if !second[1].CheckedAt.After(first[1].CheckedAt) {
+		t.Error("expected frequent target result to be refreshed")
+	}
+
+	if calls := hourlyCalls.Load(); calls != 1 {
+		t.Errorf("expected hourly target to be checked once, got %d", calls)
+	}
+
+	if calls := frequentCalls.Load(); calls < 2 {
+		t.Errorf("expected frequent target to be checked repeatedly, got %d", calls)
+	}
+
+	checker.Stop()
+
+	if _, err := checker.Results(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := hourlyCalls.Load(); calls != 2 {
+		t.Errorf("expected stopped checker to check on demand, got %d calls", calls)
+	}
+}
+
+func TestHealthChecker_StartNonPositiveInterval(t *testing.T) {
+	t.Parallel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		checker := NewHealthChecker(WithCheckInterval(interval)).
+			WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil },
+				WithTargetInterval(interval))
+
+		if checker.interval != defaultCheckInterval {
+			t.Errorf("WithCheckInterval(%s): expected the default interval, got %s", interval, checker.interval)
+		}
+
+		if err := checker.Start(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checker.Stop()
+	}
+}
+
+func TestHealthChecker_Timeout(t *testing.T) {
+	t.Parallel()
+
+	hang := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	checker := NewHealthChecker(WithDefaultTimeout(20*time.Millisecond)).
+		WithTarget("default", TargetImportanceHigh, hang).
+		WithTarget("override", TargetImportanceHigh, hang, WithTargetTimeout(40*time.Millisecond)).
+		WithTarget("healthy", TargetImportanceLow, func(ctx context.Context) error {
+			return nil
+		})
+
+	start := time.Now()
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected hanging checks to be abandoned, took %s", elapsed)
+	}
+
+	expected := []struct {
+		status  HealthTargetStatus
+		err     string
+		timeout time.Duration
+	}{
+		{HealthTargetStatusFail, "timed out after 20ms: context deadline exceeded", 20 * time.Millisecond},
+		{HealthTargetStatusFail, "timed out after 40ms: context deadline exceeded", 40 * time.Millisecond},
+		{HealthTargetStatusOk, "", 20 * time.Millisecond},
+	}
+
+	for i, result := range results {
+		if result.Status != expected[i].status {
+			t.Errorf("result[%d]: expected status %s, got %s", i, expected[i].status, result.Status)
+		}
+
+		if result.ErrorMessage != expected[i].err {
+			t.Errorf("result[%d]: expected error %q, got %q", i, expected[i].err, result.ErrorMessage)
+		}
+
+		if result.Timeout != expected[i].timeout {
+			t.Errorf("result[%d]: expected timeout %s, got %s", i, expected[i].timeout, result.Timeout)
+		}
+	}
+}
+
+func TestHealthChecker_DetailedTarget(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithDetailedTarget("replica", TargetImportanceHigh, func(ctx context.Context) (CheckReport, error) {
+			SetDetail(ctx, "host", "replica-1")
+			return CheckReport{
+				Details:       map[string]any{"lsn": "0/3000060"},
+				ObservedValue: 340,
+				ObservedUnit:  "ms",
+				Measurement:   "replicationLag",
+				Output:        "replicating",
+			}, nil
+		}).
+		WithDetailedTarget("disk", TargetImportanceLow, func(ctx context.Context) (CheckReport, error) {
+			return CheckReport{ObservedValue: 82, ObservedUnit: "percent"}, errors.New("disk almost full")
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+
+	checker.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"status":         "ok",
+			"observed_value": float64(340),
+			"observed_unit":  "ms",
+			"measurement":    "replicationLag",
+			"output":         "replicating",
+			"details":        map[string]interface{}{"host": "replica-1", "lsn": "0/3000060"},
+		},
+		{
+			"status":         "fail",
+			"error":          "disk almost full",
+			"observed_value": float64(82),
+			"observed_unit":  "percent",
+			"measurement":    "value",
+		},
+	}
+
+	for i, fields := range expected {
+		for key, value := range fields {
+			got, _ := json.Marshal(response[i][key])
+			want, _ := json.Marshal(value)
+			if string(got) != string(want) {
+				t.Errorf("result[%d]: expected %s %s, got %s", i, key, want, got)
+			}
+		}
+	}
+}
+
+func TestDegraded_Nil(t *testing.T) {
+	t.Parallel()
+
+	if err := Degraded(nil); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	checker := NewHealthChecker().
+		WithTarget("db", TargetImportanceHigh, func(ctx context.Context) error {
+			return Degraded(nil)
+		})
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected ok status, got %s", results[0].Status)
+	}
+}
+
+func TestHealthChecker_Degraded(t *testing.T) {
+	t.Parallel()
+
+	slow := func(ctx context.Context) error {
+		return Degraded(errors.New("responding slowly"))
+	}
+	fail := func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+
+	tests := []struct {
+		name           string
+		checker        *HealthChecker
+		expectedStatus int
+	}{
+		{
+			name:           "degraded high importance target returns 200 by default",
+			checker:        NewHealthChecker().WithTarget("db", TargetImportanceHigh, slow),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "degraded high importance target returns configured code",
+			checker: NewHealthChecker(WithDegradedStatusCode(http.StatusMultiStatus)).
+				WithTarget("db", TargetImportanceHigh, slow),
+			expectedStatus: http.StatusMultiStatus,
+		},
+		{
+			name: "degraded low importance target returns 200",
+			checker: NewHealthChecker(WithDegradedStatusCode(http.StatusMultiStatus)).
+				WithTarget("cache", TargetImportanceLow, slow),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failed target takes precedence",
+			checker: NewHealthChecker(WithDegradedStatusCode(http.StatusMultiStatus)).
+				WithTarget("db", TargetImportanceHigh, slow).
+				WithTarget("queue", TargetImportanceHigh, fail),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			w := httptest.NewRecorder()
+
+			tt.checker.Handler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var response []HealthCheckResult
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response[0].Status != HealthTargetStatusDegraded || response[0].ErrorMessage != "responding slowly" {
+				t.Errorf("unexpected result %+v", response[0])
+			}
+		})
+	}
+}
+
+func TestHealthChecker_LatencyThresholds(t *testing.T) {
+	t.Parallel()
+
+	slow := func(err error) HealthCheckFunc {
+		return func(ctx context.Context) error {
+			time.Sleep(30 * time.Millisecond)
+			return err
+		}
+	}
+
+	tests := []struct {
+		name           string
+		check          HealthCheckFunc
+		warn, fail     time.Duration
+		expectedStatus HealthTargetStatus
+		expectedError  string
+	}{
+		{
+			name:           "within thresholds",
+			check:          slow(nil),
+			warn:           time.Second,
+			fail:           2 * time.Second,
+			expectedStatus: HealthTargetStatusOk,
+		},
+		{
+			name:           "warn threshold exceeded",
+			check:          slow(nil),
+			warn:           10 * time.Millisecond,
+			fail:           time.Second,
+			expectedStatus: HealthTargetStatusDegraded,
+			expectedError:  "exceeds warn threshold 10ms",
+		},
+		{
+			name:           "fail threshold exceeded",
+			check:          slow(nil),
+			warn:           5 * time.Millisecond,
+			fail:           10 * time.Millisecond,
+			expectedStatus: HealthTargetStatusFail,
+			expectedError:  "exceeds fail threshold 10ms",
+		},
+		{
+			name:           "degraded check exceeding fail threshold",
+			check:          slow(Degraded(errors.New("replication lag"))),
+			fail:           10 * time.Millisecond,
+			expectedStatus: HealthTargetStatusFail,
+			expectedError:  "exceeds fail threshold 10ms",
+		},
+		{
+			name:           "degraded check exceeding warn threshold",
+			check:          slow(Degraded(errors.New("replication lag"))),
+			warn:           10 * time.Millisecond,
+			expectedStatus: HealthTargetStatusDegraded,
+			expectedError:  "replication lag",
+		},
+		{
+			name:           "failed check keeps its error",
+			check:          slow(errors.New("connection refused")),
+			warn:           5 * time.Millisecond,
+			fail:           10 * time.Millisecond,
+			expectedStatus: HealthTargetStatusFail,
+			expectedError:  "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewHealthChecker().
+				WithTarget("api", TargetImportanceHigh, tt.check, WithLatencyThresholds(tt.warn, tt.fail))
+
+			results, err := checker.Check(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := results[0]
+
+			if result.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, result.Status)
+			}
+
+			if !strings.Contains(result.ErrorMessage, tt.expectedError) {
+				t.Errorf("expected error containing %q, got %q", tt.expectedError, result.ErrorMessage)
+			}
+
+			if result.LatencyWarn != tt.warn || result.LatencyFail != tt.fail {
+				t.Errorf("expected thresholds %s/%s, got %s/%s", tt.warn, tt.fail, result.LatencyWarn, result.LatencyFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package status
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// healthJSONContentType is the media type of the Health Check Response
+// Format for HTTP APIs.
+const healthJSONContentType = "application/health+json"
+
+// ResponseFormat defines the format of the health check responses.
+type ResponseFormat string
+
+const (
+	// ResponseFormatJSON responds with a JSON array of HealthCheckResult.
+	ResponseFormatJSON = ResponseFormat("json")
+	// ResponseFormatHealthJSON responds in the Health Check Response Format
+	// for HTTP APIs (application/health+json).
+	ResponseFormatHealthJSON = ResponseFormat("health+json")
+)
+
+// WithResponseFormat sets the format of the health check responses. Clients
+// may request application/health+json regardless of it via the Accept header.
+func WithResponseFormat(format ResponseFormat) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.format = format
+	}
+}
+
+// WithRelease sets the version and release ID of the service reported in
+// application/health+json responses.
+func WithRelease(version, releaseID string) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.version = version
+		c.releaseID = releaseID
+	}
+}
+
+// healthStatus is a status of the Health Check Response Format.
+type healthStatus string
+
+const (
+	healthStatusPass = healthStatus("pass")
+	healthStatusWarn = healthStatus("warn")
+	healthStatusFail = healthStatus("fail")
+)
+
+// healthResponse is a response body in the Health Check Response Format.
+type healthResponse struct {
+	Status    healthStatus             `json:"status"`
+	Version   string                   `json:"version,omitempty"`
+	ReleaseID string                   `json:"releaseId,omitempty"`
+	Checks    map[string][]healthCheck `json:"checks,omitempty"`
+}
+
+// healthCheck is a single measurement of a component in the Health Check
+// Response Format.
+type healthCheck struct {
+	ObservedValue any          `json:"observedValue,omitempty"`
+	ObservedUnit  string       `json:"observedUnit,omitempty"`
+	Status        healthStatus `json:"status"`
+	Time          time.Time    `json:"time"`
+	Output        string       `json:"output,omitempty"`
+}
+
+// healthResponse converts the results into the Health Check Response Format.
+// Failures of low importance targets are reported as warnings.
+func (c *HealthChecker) healthResponse(results []HealthCheckResult) healthResponse {
+	resp := healthResponse{
+		Status:    healthStatusPass,
+		Version:   c.version,
+		ReleaseID: c.releaseID,
+		Checks:    make(map[string][]healthCheck, len(results)),
+	}
+
+	for _, result := range results {
+		status := resultHealthStatus(result)
+
+		switch {
+		case status == healthStatusFail:
+			resp.Status = healthStatusFail
+		case status == healthStatusWarn && resp.Status == healthStatusPass:
+			resp.Status = healthStatusWarn
+		}
+
+		key := result.Target.Name + ":responseTime"
+		resp.Checks[key] = append(resp.Checks[key], healthCheck{
+			ObservedValue: float64(result.Duration) / float64(time.Millisecond),
+			ObservedUnit:  "ms",
+			Status:        status,
+			Time:          result.CheckedAt,
+			Output:        resultOutput(result),
+		})
+
+		if result.ObservedValue != nil {
+			key := result.Target.Name + ":" + result.Measurement
+			resp.Checks[key] = append(resp.Checks[key], healthCheck{
+				ObservedValue: result.ObservedValue,
+				ObservedUnit:  result.ObservedUnit,
+				Status:        status,
+				Time:          result.CheckedAt,
+				Output:        resultOutput(result),
+			})
+		}
+	}
+
+	return resp
+}
+
+// resultHealthStatus maps the result status to the Health Check Response
+// Format status. Degraded targets are reported as warnings, as well as the
+// skipped ones, whose failure is reported by the dependency that caused them
+// to be skipped.
+func resultHealthStatus(result HealthCheckResult) healthStatus {
+	switch {
+	case result.Status == HealthTargetStatusOk:
+		return healthStatusPass
+	case result.Status == HealthTargetStatusDegraded,
+		result.Status == HealthTargetStatusSkipped,
+		result.Status == HealthTargetStatusMaintenance,
+		result.Target.Importance == TargetImportanceLow:
+		return healthStatusWarn
+	default:
+		return healthStatusFail
+	}
+}
+
+// resultOutput returns the human readable output of the result.
+func resultOutput(result HealthCheckResult) string {
+	switch {
+	case result.Maintenance != nil:
+		return "under maintenance: " + result.Maintenance.Reason
+	case result.SkippedBy != "":
+		return "skipped because " + result.SkippedBy + " is unhealthy"
+	case result.ErrorMessage != "":
+		return result.ErrorMessage
+	default:
+		return result.Output
+	}
+}
+
+// acceptsHealthJSON reports whether the client explicitly accepts
+// application/health+json responses.
+func acceptsHealthJSON(r *http.Request) bool {
+	return accepts(r, healthJSONContentType)
+}
+
+// accepts reports whether the client explicitly accepts responses of the
+// given media type.
+func accepts(r *http.Request, mediaType string) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		parsed, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && parsed == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
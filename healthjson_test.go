@@ -0,0 +1,181 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthChecker_HandlerHealthJSON(t *testing.T) {
+	t.Parallel()
+
+	ok := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name           string
+		checker        *HealthChecker
+		accept         string
+		expectedType   string
+		expectedStatus int
+		expectedBody   healthStatus
+		expectedChecks map[string]healthStatus
+	}{
+		{
+			name:           "plain json by default",
+			checker:        NewHealthChecker().WithTarget("db", TargetImportanceHigh, ok),
+			expectedType:   "application/json",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "health json via accept header",
+			checker:        NewHealthChecker().WithTarget("db", TargetImportanceHigh, ok),
+			accept:         "application/json;q=0.9, application/health+json",
+			expectedType:   "application/health+json",
+			expectedStatus: http.StatusOK,
+			expectedBody:   healthStatusPass,
+			expectedChecks: map[string]healthStatus{"db:responseTime": healthStatusPass},
+		},
+		{
+			name: "low importance failure is a warning",
+			checker: NewHealthChecker(WithResponseFormat(ResponseFormatHealthJSON)).
+				WithTarget("db", TargetImportanceHigh, ok).
+				WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error {
+					return errors.New("cache miss")
+				}),
+			expectedType:   "application/health+json",
+			expectedStatus: http.StatusOK,
+			expectedBody:   healthStatusWarn,
+			expectedChecks: map[string]healthStatus{
+				"db:responseTime":    healthStatusPass,
+				"cache:responseTime": healthStatusWarn,
+			},
+		},
+		{
+			name: "degraded target is a warning",
+			checker: NewHealthChecker(WithResponseFormat(ResponseFormatHealthJSON)).
+				WithTarget("db", TargetImportanceHigh, func(ctx context.Context) error {
+					return Degraded(errors.New("responding slowly"))
+				}),
+			expectedType:   "application/health+json",
+			expectedStatus: http.StatusOK,
+			expectedBody:   healthStatusWarn,
+			expectedChecks: map[string]healthStatus{"db:responseTime": healthStatusWarn},
+		},
+		{
+			name: "high importance failure is a failure",
+			checker: NewHealthChecker(WithResponseFormat(ResponseFormatHealthJSON)).
+				WithTarget("db", TargetImportanceHigh, func(ctx context.Context) error {
+					return errors.New("connection refused")
+				}).
+				WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error {
+					return errors.New("cache miss")
+				}),
+			expectedType:   "application/health+json",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   healthStatusFail,
+			expectedChecks: map[string]healthStatus{
+				"db:responseTime":    healthStatusFail,
+				"cache:responseTime": healthStatusWarn,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			tt.checker.Handler().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedType {
+				t.Errorf("expected content type %s, got %s", tt.expectedType, contentType)
+			}
+
+			if tt.expectedBody == "" {
+				return
+			}
+
+			var response healthResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response.Status != tt.expectedBody {
+				t.Errorf("expected status %s, got %s", tt.expectedBody, response.Status)
+			}
+
+			if len(response.Checks) != len(tt.expectedChecks) {
+				t.Errorf("expected %d checks, got %d", len(tt.expectedChecks), len(response.Checks))
+			}
+
+			for key, expected := range tt.expectedChecks {
+				checks := response.Checks[key]
+				if len(checks) != 1 {
+					t.Errorf("%s: expected 1 check, got %d", key, len(checks))
+					continue
+				}
+
+				if checks[0].Status != expected {
+					t.Errorf("%s: expected status %s, got %s", key, expected, checks[0].Status)
+				}
+
+				if checks[0].ObservedUnit != "ms" || checks[0].Time.IsZero() {
+					t.Errorf("%s: expected response time measurement, got %+v", key, checks[0])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthChecker_HealthResponseObservedValue(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker()
+
+	resp := checker.healthResponse([]HealthCheckResult{{
+		Target:        HealthTarget{Name: "disk", Importance: TargetImportanceLow},
+		Status:        HealthTargetStatusOk,
+		ObservedValue: 82,
+		ObservedUnit:  "percent",
+		Measurement:   "utilization",
+		Output:        "82% used",
+	}})
+
+	checks := resp.Checks["disk:utilization"]
+	if len(checks) != 1 {
+		t.Fatalf("expected 1 utilization check, got %d", len(checks))
+	}
+
+	if checks[0].ObservedValue != 82 || checks[0].ObservedUnit != "percent" || checks[0].Output != "82% used" {
+		t.Errorf("unexpected check %+v", checks[0])
+	}
+
+	if len(resp.Checks["disk:responseTime"]) != 1 {
+		t.Errorf("expected response time check to be kept, got %v", resp.Checks)
+	}
+}
+
+func TestHealthChecker_HealthResponseRelease(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithRelease("1", "1.2.3-abcdef"))
+
+	resp := checker.healthResponse(nil)
+	if resp.Version != "1" || resp.ReleaseID != "1.2.3-abcdef" {
+		t.Errorf("unexpected release info %q, %q", resp.Version, resp.ReleaseID)
+	}
+
+	if resp.Status != healthStatusPass {
+		t.Errorf("expected status %s, got %s", healthStatusPass, resp.Status)
+	}
+}
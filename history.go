@@ -0,0 +1,207 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// historyBarSize is the maximum number of latest results kept in
+// TargetHistory for rendering.
+const historyBarSize = 90
+
+// historyPruneInterval is how often the checker prunes the results that fall
+// out of the history window.
+const historyPruneInterval = time.Hour
+
+// HistoryStore stores the results of the checks.
+type HistoryStore interface {
+	// Append stores the result of a check.
+	Append(ctx context.Context, result HealthCheckResult) error
+	// Query returns the results of the target checked within [from, to],
+	// oldest first.
+	Query(ctx context.Context, target string, from, to time.Time) ([]HealthCheckResult, error)
+	// Prune removes the results checked before the given time.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// BatchHistoryStore is a HistoryStore able to return the results of all
+// targets at once, e.g. to read its storage once instead of once per target.
+// Histories uses QueryAll when the store implements it.
+type BatchHistoryStore interface {
+	HistoryStore
+	// QueryAll returns the results of all targets checked within [from, to]
+	// keyed by target name, oldest first.
+	QueryAll(ctx context.Context, from, to time.Time) (map[string][]HealthCheckResult, error)
+}
+
+// WithHistory makes the checker keep the results of up to size latest checks
+// of every target in memory, not older than window. Zero window keeps results
+// of any age.
+func WithHistory(size int, window time.Duration) HealthCheckerOption {
+	return WithHistoryStore(NewMemoryHistoryStore(size), window)
+}
+
+// WithHistoryStore makes the checker write the result of every check to the
+// store and summarize the results not older than window in the history.
+// Results older than window are pruned from the store hourly. Zero window
+// keeps results of any age.
+func WithHistoryStore(store HistoryStore, window time.Duration) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.history = store
+		c.historyWindow = window
+	}
+}
+
+// TargetHistory summarizes the results of a target within the history
+// window. Results holds up to 90 latest of them, oldest first.
+type TargetHistory struct {
+	Results        []HealthCheckResult `json:"results"`
+	Uptime         float64             `json:"uptime"`
+	AverageLatency time.Duration       `json:"average_latency"`
+}
+
+// History returns the history of the target with the given name. It is empty
+// unless the checker is created with WithHistory or WithHistoryStore.
+func (c *HealthChecker) History(ctx context.Context, name string) (TargetHistory, error) {
+	if c.history == nil {
+		return TargetHistory{}, nil
+	}
+
+	from, to := c.historyRange()
+
+	results, err := c.history.Query(ctx, name, from, to)
+	if err != nil {
+		return TargetHistory{}, fmt.Errorf("querying history of %s: %w", name, err)
+	}
+
+	return summarizeHistory(results), nil
+}
+
+// Histories returns the histories of all targets keyed by target name. It is
+// nil unless the checker is created with WithHistory or WithHistoryStore.
+func (c *HealthChecker) Histories(ctx context.Context) (map[string]TargetHistory, error) {
+	if c.history == nil {
+		return nil, nil
+	}
+
+	targets := c.targetList()
+
+	batch, ok := c.history.(BatchHistoryStore)
+	if !ok {
+		histories := make(map[string]TargetHistory, len(targets))
+		for _, target := range targets {
+			history, err := c.History(ctx, target.Name)
+			if err != nil {
+				return nil, err
+			}
+			histories[target.Name] = history
+		}
+
+		return histories, nil
+	}
+
+	from, to := c.historyRange()
+
+	results, err := batch.QueryAll(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying history: %w", err)
+	}
+
+	histories := make(map[string]TargetHistory, len(targets))
+	for _, target := range targets {
+		histories[target.Name] = summarizeHistory(results[target.Name])
+	}
+
+	return histories, nil
+}
+
+// historyRange returns the time range of the history window ending now.
+func (c *HealthChecker) historyRange() (from, to time.Time) {
+	to = time.Now()
+
+	if c.historyWindow > 0 {
+		from = to.Add(-c.historyWindow)
+	}
+
+	return from, to
+}
+
+// appendHistory writes the result to the history store, if any, and prunes
+// the store when due.
+func (c *HealthChecker) appendHistory(ctx context.Context, result HealthCheckResult) {
+	if c.history == nil {
+		return
+	}
+
+	if err := c.history.Append(ctx, result); err != nil {
+		log.Printf("appending %s result to history: %v", result.Target.Name, err)
+	}
+
+	c.pruneHistory(ctx)
+}
+
+// pruneHistory removes the results that fall out of the history window, at
+// most once per historyPruneInterval, so the store doesn't grow without bound
+// whether or not the checker is started.
+func (c *HealthChecker) pruneHistory(ctx context.Context) {
+	if c.historyWindow <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	last := c.historyPrunedAt.Load()
+	if last != 0 && now.Sub(time.Unix(0, last)) < historyPruneInterval {
+		return
+	}
+
+	if !c.historyPrunedAt.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	if err := c.history.Prune(ctx, now.Add(-c.historyWindow)); err != nil {
+		log.Printf("pruning history: %v", err)
+	}
+}
+
+// summarizeHistory calculates the uptime percentage and the average latency
+// of the results. Degraded targets count as up and results under maintenance
+// are left out of the uptime.
+func summarizeHistory(results []HealthCheckResult) TargetHistory {
+	h := TargetHistory{
+		Results: results,
+	}
+
+	if len(results) == 0 {
+		return h
+	}
+
+	var ok, counted int
+	var latency time.Duration
+
+	for _, result := range results {
+		switch result.Status {
+		case HealthTargetStatusMaintenance:
+		case HealthTargetStatusOk, HealthTargetStatusDegraded:
+			ok++
+			counted++
+		default:
+			counted++
+		}
+		latency += result.Duration
+	}
+
+	h.Uptime = 100
+	if counted > 0 {
+		h.Uptime = float64(ok) / float64(counted) * 100
+	}
+	h.AverageLatency = latency / time.Duration(len(results))
+
+	if len(h.Results) > historyBarSize {
+		h.Results = h.Results[len(h.Results)-historyBarSize:]
+	}
+
+	return h
+}
@@ -0,0 +1,360 @@
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MemoryHistoryStore is a HistoryStore keeping a bounded number of latest
+// results of every target in memory.
+type MemoryHistoryStore struct {
+	mu      sync.Mutex
+	size    int
+	results map[string][]HealthCheckResult
+}
+
+// NewMemoryHistoryStore creates a MemoryHistoryStore keeping up to size
+// latest results of every target. Negative size keeps none, as does zero.
+func NewMemoryHistoryStore(size int) *MemoryHistoryStore {
+	return &MemoryHistoryStore{
+		size:    max(size, 0),
+		results: make(map[string][]HealthCheckResult),
+	}
+}
+
+// Append stores the result dropping the oldest results beyond the size.
+func (s *MemoryHistoryStore) Append(_ context.Context, result HealthCheckResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := append(s.results[result.Target.Name], result)
+	if len(results) > s.size {
+		results = results[len(results)-s.size:]
+	}
+
+	s.results[result.Target.Name] = results
+
+	return nil
+}
+
+// Query returns the stored results of the target checked within [from, to],
+// oldest first.
+func (s *MemoryHistoryStore) Query(_ context.Context, target string, from, to time.Time) ([]HealthCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []HealthCheckResult
+	for _, result := range s.results[target] {
+		if inRange(result, from, to) {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// QueryAll returns the stored results of all targets checked within
+// [from, to] keyed by target name, oldest first.
+func (s *MemoryHistoryStore) QueryAll(_ context.Context, from, to time.Time) (map[string][]HealthCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make(map[string][]HealthCheckResult, len(s.results))
+	for target, stored := range s.results {
+		for _, result := range stored {
+			if inRange(result, from, to) {
+				results[target] = append(results[target], result)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// Prune removes the results checked before the given time.
+func (s *MemoryHistoryStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for target, results := range s.results {
+		for len(results) > 0 && results[0].CheckedAt.Before(before) {
+			results = results[1:]
+		}
+
+		if len(results) == 0 {
+			delete(s.results, target)
+		} else {
+			s.results[target] = results
+		}
+	}
+
+	return nil
+}
+
+// maxHistoryLineSize is the maximum size of a single result in the file of
+// FileHistoryStore.
+const maxHistoryLineSize = 1 << 20
+
+// FileHistoryStore is a HistoryStore appending the results to a file as
+// JSON lines, so that the history survives restarts.
+type FileHistoryStore struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileHistoryStore creates a FileHistoryStore writing to the file at
+// path. The file is created if it doesn't exist. A partially written last
+// line, e.g. left by a crash, is truncated so that new results aren't joined
+// onto it.
+func NewFileHistoryStore(path string) (*FileHistoryStore, error) {
+	if err := truncatePartialLine(path); err != nil {
+		return nil, err
+	}
+
+	file, err := openHistoryFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileHistoryStore{
+		path: path,
+		file: file,
+	}, nil
+}
+
+// Append writes the result to the end of the file.
+func (s *FileHistoryStore) Append(_ context.Context, result HealthCheckResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshaling result: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing history file: %w", err)
+	}
+
+	return nil
+}
+
+// Query reads the results of the target checked within [from, to] from the
+// file, oldest first.
+func (s *FileHistoryStore) Query(ctx context.Context, target string, from, to time.Time) ([]HealthCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []HealthCheckResult
+
+	err := s.scan(ctx, func(result HealthCheckResult, _ []byte) error {
+		if result.Target.Name == target && inRange(result, from, to) {
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// QueryAll reads the results of all targets checked within [from, to] from
+// the file, keyed by target name, oldest first.
+func (s *FileHistoryStore) QueryAll(ctx context.Context, from, to time.Time) (map[string][]HealthCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make(map[string][]HealthCheckResult)
+
+	err := s.scan(ctx, func(result HealthCheckResult, _ []byte) error {
+		if inRange(result, from, to) {
+			results[result.Target.Name] = append(results[result.Target.Name], result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Prune rewrites the file without the results checked before the given
+// time. Malformed lines are dropped as well.
+func (s *FileHistoryStore) Prune(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating temporary history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+
+	err = s.scan(ctx, func(result HealthCheckResult, line []byte) error {
+		if result.CheckedAt.Before(before) {
+			return nil
+		}
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		return w.WriteByte('\n')
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing temporary history file: %w", err)
+	}
+
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("closing history file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing history file: %w", err)
+	}
+
+	s.file, err = openHistoryFile(s.path)
+
+	return err
+}
+
+// Close closes the file.
+func (s *FileHistoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// scan calls fn for every result in the file along with its line. Malformed
+// lines are skipped, so that a damaged line doesn't make the whole history
+// unreadable.
+func (s *FileHistoryStore) scan(ctx context.Context, fn func(HealthCheckResult, []byte) error) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("opening history file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxHistoryLineSize)
+
+	var skipped int
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var result HealthCheckResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			skipped++
+			continue
+		}
+
+		if err := fn(result, scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading history file: %w", err)
+	}
+
+	if skipped > 0 {
+		log.Printf("skipped %d malformed lines of history file %s", skipped, s.path)
+	}
+
+	return nil
+}
+
+// truncatePartialLine truncates the file at path after its last newline, if
+// it exists and doesn't end with one.
+func truncatePartialLine(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("opening history file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("reading history file: %w", err)
+	}
+
+	// Read backwards in chunks until the last newline.
+	size := info.Size()
+	buf := make([]byte, 4096)
+
+	for end := size; end > 0; {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+
+		if _, err := file.ReadAt(chunk, start); err != nil {
+			return fmt.Errorf("reading history file: %w", err)
+		}
+
+		i := bytes.LastIndexByte(chunk, '\n')
+		if i >= 0 {
+			return truncateHistoryFile(file, start+int64(i)+1, size)
+		}
+
+		end = start
+	}
+
+	return truncateHistoryFile(file, 0, size)
+}
+
+// truncateHistoryFile truncates the file to length unless it already has the
+// length of size.
+func truncateHistoryFile(file *os.File, length, size int64) error {
+	if length == size {
+		return nil
+	}
+
+	log.Printf("truncating partially written line of history file %s", file.Name())
+
+	if err := file.Truncate(length); err != nil {
+		return fmt.Errorf("truncating history file: %w", err)
+	}
+
+	return nil
+}
+
+func openHistoryFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening history file: %w", err)
+	}
+
+	return file, nil
+}
+
+// inRange reports whether the result was checked within [from, to]. Zero
+// bounds are open.
+func inRange(result HealthCheckResult, from, to time.Time) bool {
+	return (from.IsZero() || !result.CheckedAt.Before(from)) &&
+		(to.IsZero() || !result.CheckedAt.After(to))
+}
@@ -0,0 +1,214 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryStores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		store func(t *testing.T) HistoryStore
+	}{
+		{
+			name: "memory",
+			store: func(t *testing.T) HistoryStore {
+				return NewMemoryHistoryStore(10)
+			},
+		},
+		{
+			name: "file",
+			store: func(t *testing.T) HistoryStore {
+				store, err := NewFileHistoryStore(filepath.Join(t.TempDir(), "history.jsonl"))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				t.Cleanup(func() { store.Close() })
+				return store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := tt.store(t)
+			now := time.Now().Truncate(time.Second)
+
+			for i, name := range []string{"database", "cache", "database", "database"} {
+				err := store.Append(ctx, HealthCheckResult{
+					Target:       HealthTarget{Name: name, Importance: TargetImportanceHigh},
+					Status:       HealthTargetStatusFail,
+					ErrorMessage: "connection refused",
+					Duration:     time.Duration(i) * time.Millisecond,
+					CheckedAt:    now.Add(time.Duration(i) * time.Minute),
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			results, err := store.Query(ctx, "database", now.Add(time.Minute), time.Time{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != 2 {
+				t.Fatalf("expected 2 results, got %d", len(results))
+			}
+
+			if !results[0].CheckedAt.Equal(now.Add(2*time.Minute)) || results[0].Duration != 2*time.Millisecond {
+				t.Errorf("unexpected first result %+v", results[0])
+			}
+
+			if results[0].ErrorMessage != "connection refused" || results[0].Target.Importance != TargetImportanceHigh {
+				t.Errorf("expected result to be stored as is, got %+v", results[0])
+			}
+
+			all, err := store.(BatchHistoryStore).QueryAll(ctx, now.Add(time.Minute), time.Time{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(all) != 2 || len(all["database"]) != 2 || len(all["cache"]) != 1 {
+				t.Errorf("unexpected results of all targets %+v", all)
+			}
+
+			if err := store.Prune(ctx, now.Add(2*time.Minute)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			results, err = store.Query(ctx, "database", time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != 2 {
+				t.Errorf("expected 2 results after prune, got %d", len(results))
+			}
+
+			results, err = store.Query(ctx, "cache", time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != 0 {
+				t.Errorf("expected cache results to be pruned, got %d", len(results))
+			}
+
+			err = store.Append(ctx, HealthCheckResult{
+				Target:    HealthTarget{Name: "cache"},
+				CheckedAt: now.Add(time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error appending after prune: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileHistoryStore_Reopen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileHistoryStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Append(ctx, HealthCheckResult{Target: HealthTarget{Name: "database"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store, err = NewFileHistoryStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+
+	results, err := store.Query(ctx, "database", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("expected history to survive reopening, got %d results", len(results))
+	}
+}
+
+func TestFileHistoryStore_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	content := `{"target":{"name":"database"},"status":"ok","checked_at":"2024-01-01T00:00:00Z"}
+not json
+{"target":{"name":"database"},"status":"fail","checked_at":"2024-01-01T00:01:00Z"}
+{"target":{"name":"datab`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store, err := NewFileHistoryStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+
+	err = store.Append(ctx, HealthCheckResult{
+		Target:    HealthTarget{Name: "database"},
+		Status:    HealthTargetStatusOk,
+		CheckedAt: time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := store.Query(ctx, "database", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []HealthTargetStatus{HealthTargetStatusOk, HealthTargetStatusFail, HealthTargetStatusOk}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+
+	for i, result := range results {
+		if result.Status != expected[i] {
+			t.Errorf("result[%d]: expected status %s, got %s", i, expected[i], result.Status)
+		}
+	}
+}
+
+func TestMemoryHistoryStore_NegativeSize(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithHistory(-1, 0)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history, err := checker.History(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(history.Results) != 0 {
+		t.Errorf("expected no results kept, got %d", len(history.Results))
+	}
+}
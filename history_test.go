@@ -0,0 +1,221 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_History(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := NewHealthChecker(WithHistory(3, 0)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			if calls.Add(1)%2 == 0 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+
+	for range 4 {
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	history, err := checker.History(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(history.Results) != 3 {
+		t.Fatalf("expected history to be bounded to 3 results, got %d", len(history.Results))
+	}
+
+	expected := []HealthTargetStatus{HealthTargetStatusFail, HealthTargetStatusOk, HealthTargetStatusFail}
+	for i, result := range history.Results {
+		if result.Status != expected[i] {
+			t.Errorf("result[%d]: expected status %s, got %s", i, expected[i], result.Status)
+		}
+	}
+
+	if uptime := 100.0 / 3; math.Abs(history.Uptime-uptime) > 1e-9 {
+		t.Errorf("expected uptime %f, got %f", uptime, history.Uptime)
+	}
+
+	if history.AverageLatency <= 0 {
+		t.Errorf("expected positive average latency, got %s", history.AverageLatency)
+	}
+
+	unknown, err := checker.History(context.Background(), "unknown")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(unknown.Results) != 0 || unknown.Uptime != 0 {
+		t.Errorf("expected empty history of unknown target, got %+v", unknown)
+	}
+}
+
+func TestHealthChecker_HistoryWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	store := NewMemoryHistoryStore(10)
+
+	for _, age := range []time.Duration{3 * time.Hour, 2 * time.Hour, 30 * time.Minute, time.Minute} {
+		err := store.Append(context.Background(), HealthCheckResult{
+			Target:    HealthTarget{Name: "database"},
+			Status:    HealthTargetStatusOk,
+			Duration:  age / 1000,
+			CheckedAt: now.Add(-age),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	checker := NewHealthChecker(WithHistoryStore(store, time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return nil
+		})
+
+	history, err := checker.History(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(history.Results) != 2 {
+		t.Fatalf("expected 2 results in window, got %d", len(history.Results))
+	}
+
+	if !history.Results[0].CheckedAt.Equal(now.Add(-30 * time.Minute)) {
+		t.Errorf("expected oldest result in window first, got %s", history.Results[0].CheckedAt)
+	}
+
+	if history.Uptime != 100 {
+		t.Errorf("expected uptime 100, got %f", history.Uptime)
+	}
+
+	if expected := (30*time.Minute + time.Minute) / 2000; history.AverageLatency != expected {
+		t.Errorf("expected average latency %s, got %s", expected, history.AverageLatency)
+	}
+}
+
+func TestSummarizeHistory_BarSize(t *testing.T) {
+	t.Parallel()
+
+	results := make([]HealthCheckResult, historyBarSize+10)
+	for i := range results {
+		results[i].Status = HealthTargetStatusOk
+		if i < 10 {
+			results[i].Status = HealthTargetStatusFail
+		}
+	}
+
+	history := summarizeHistory(results)
+
+	if len(history.Results) != historyBarSize {
+		t.Errorf("expected %d results, got %d", historyBarSize, len(history.Results))
+	}
+
+	if history.Results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected oldest results to be dropped, got %s", history.Results[0].Status)
+	}
+
+	if expected := float64(historyBarSize) / float64(historyBarSize+10) * 100; history.Uptime != expected {
+		t.Errorf("expected uptime over all results %f, got %f", expected, history.Uptime)
+	}
+}
+
+// countingHistoryStore counts the queries and prunes of the wrapped store.
+type countingHistoryStore struct {
+	*MemoryHistoryStore
+	queries, batchQueries, prunes atomic.Int32
+}
+
+func (s *countingHistoryStore) Prune(ctx context.Context, before time.Time) error {
+	s.prunes.Add(1)
+	return s.MemoryHistoryStore.Prune(ctx, before)
+}
+
+func (s *countingHistoryStore) Query(ctx context.Context, target string, from, to time.Time) ([]HealthCheckResult, error) {
+	s.queries.Add(1)
+	return s.MemoryHistoryStore.Query(ctx, target, from, to)
+}
+
+func (s *countingHistoryStore) QueryAll(ctx context.Context, from, to time.Time) (map[string][]HealthCheckResult, error) {
+	s.batchQueries.Add(1)
+	return s.MemoryHistoryStore.QueryAll(ctx, from, to)
+}
+
+func TestHealthChecker_HistoriesBatch(t *testing.T) {
+	t.Parallel()
+
+	store := &countingHistoryStore{MemoryHistoryStore: NewMemoryHistoryStore(10)}
+	check := func(ctx context.Context) error { return nil }
+
+	checker := NewHealthChecker(WithHistoryStore(store, time.Hour)).
+		WithTarget("database", TargetImportanceHigh, check).
+		WithTarget("cache", TargetImportanceLow, check).
+		WithTarget("queue", TargetImportanceLow, check)
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	histories, err := checker.Histories(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(histories) != 3 || len(histories["cache"].Results) != 1 {
+		t.Errorf("unexpected histories %+v", histories)
+	}
+
+	if q, b := store.queries.Load(), store.batchQueries.Load(); q != 0 || b != 1 {
+		t.Errorf("expected a single batch query, got %d queries and %d batch queries", q, b)
+	}
+}
+
+func TestHealthChecker_HistoryPruneWithoutStart(t *testing.T) {
+	t.Parallel()
+
+	store := &countingHistoryStore{MemoryHistoryStore: NewMemoryHistoryStore(10)}
+
+	err := store.Append(context.Background(), HealthCheckResult{
+		Target:    HealthTarget{Name: "database"},
+		Status:    HealthTargetStatusOk,
+		CheckedAt: time.Now().Add(-3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checker := NewHealthChecker(WithHistoryStore(store, time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+	for range 3 {
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := store.prunes.Load(); n != 1 {
+		t.Errorf("expected a single prune within the prune interval, got %d", n)
+	}
+
+	results, err := store.Query(context.Background(), "database", time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Errorf("expected the result out of the window to be pruned, got %d results", len(results))
+	}
+}
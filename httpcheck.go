@@ -0,0 +1,165 @@
+package status
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxHTTPCheckBodySize is the maximum size of the response body read by
+// HTTPCheck to match it.
+const maxHTTPCheckBodySize = 1 << 20
+
+// HTTPCheckOption is a function that configures HTTPCheck.
+type HTTPCheckOption func(*httpCheck)
+
+// WithHTTPMethod sets the method of the request. Defaults to GET.
+func WithHTTPMethod(method string) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.method = method
+	}
+}
+
+// WithHTTPExpectedStatus sets the status codes of a healthy response.
+// Defaults to any 2xx status code.
+func WithHTTPExpectedStatus(codes ...int) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.statuses = append(c.statuses, codes...)
+	}
+}
+
+// WithHTTPBodyContains requires the response body to contain s.
+func WithHTTPBodyContains(s string) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.bodyContains = s
+	}
+}
+
+// WithHTTPBodyRegexp requires the response body to match re.
+func WithHTTPBodyRegexp(re *regexp.Regexp) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.bodyRegexp = re
+	}
+}
+
+// WithHTTPHeader adds a header to the request.
+func WithHTTPHeader(key, value string) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithHTTPTLSConfig sets the TLS configuration of the default client. It
+// has no effect together with WithHTTPClient.
+func WithHTTPTLSConfig(cfg *tls.Config) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithHTTPMaxLatency fails the check when the response takes longer than d.
+func WithHTTPMaxLatency(d time.Duration) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.maxLatency = d
+	}
+}
+
+// WithHTTPClient sets the client sending the request.
+func WithHTTPClient(client *http.Client) HTTPCheckOption {
+	return func(c *httpCheck) {
+		c.client = client
+	}
+}
+
+// httpCheck is the configuration of HTTPCheck.
+type httpCheck struct {
+	url          string
+	method       string
+	statuses     []int
+	bodyContains string
+	bodyRegexp   *regexp.Regexp
+	header       http.Header
+	tlsConfig    *tls.Config
+	maxLatency   time.Duration
+	client       *http.Client
+}
+
+// HTTPCheck returns a HealthCheckFunc that sends a request to the url and
+// checks the response.
+func HTTPCheck(url string, opts ...HTTPCheckOption) HealthCheckFunc {
+	c := &httpCheck{
+		url:    url,
+		method: http.MethodGet,
+		header: make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if c.tlsConfig != nil {
+			transport.TLSClientConfig = c.tlsConfig
+		}
+		c.client = &http.Client{Transport: transport}
+	}
+
+	return c.check
+}
+
+func (c *httpCheck) check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+
+	start := time.Now()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPCheckBodySize))
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if latency := time.Since(start); c.maxLatency > 0 && latency > c.maxLatency {
+		return fmt.Errorf("latency %s exceeds %s", latency, c.maxLatency)
+	}
+
+	if !c.expectedStatus(resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if c.bodyContains != "" && !strings.Contains(string(body), c.bodyContains) {
+		return fmt.Errorf("response body doesn't contain %q", c.bodyContains)
+	}
+
+	if c.bodyRegexp != nil && !c.bodyRegexp.Match(body) {
+		return fmt.Errorf("response body doesn't match %q", c.bodyRegexp)
+	}
+
+	return nil
+}
+
+func (c *httpCheck) expectedStatus(code int) bool {
+	if len(c.statuses) == 0 {
+		return code >= 200 && code < 300
+	}
+
+	return slices.Contains(c.statuses, code)
+}
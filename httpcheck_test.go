@@ -0,0 +1,162 @@
+package status
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			fmt.Fprint(w, `{"status":"ok","version":"1.2.3"}`)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/created":
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "/auth":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		opts     []HTTPCheckOption
+		expected string
+	}{
+		{
+			name: "2xx status code is healthy",
+			path: "/ok",
+		},
+		{
+			name:     "non 2xx status code is unhealthy",
+			path:     "/unavailable",
+			expected: "unexpected status code 503",
+		},
+		{
+			name: "expected status code",
+			path: "/unavailable",
+			opts: []HTTPCheckOption{WithHTTPExpectedStatus(http.StatusOK, http.StatusServiceUnavailable)},
+		},
+		{
+			name: "method",
+			path: "/created",
+			opts: []HTTPCheckOption{WithHTTPMethod(http.MethodPost), WithHTTPExpectedStatus(http.StatusCreated)},
+		},
+		{
+			name: "header",
+			path: "/auth",
+			opts: []HTTPCheckOption{WithHTTPHeader("Authorization", "Bearer token")},
+		},
+		{
+			name:     "missing header",
+			path:     "/auth",
+			expected: "unexpected status code 401",
+		},
+		{
+			name: "body contains",
+			path: "/ok",
+			opts: []HTTPCheckOption{WithHTTPBodyContains(`"status":"ok"`)},
+		},
+		{
+			name:     "body doesn't contain",
+			path:     "/ok",
+			opts:     []HTTPCheckOption{WithHTTPBodyContains("fail")},
+			expected: `response body doesn't contain "fail"`,
+		},
+		{
+			name: "body matches",
+			path: "/ok",
+			opts: []HTTPCheckOption{WithHTTPBodyRegexp(regexp.MustCompile(`"version":"1\.\d+\.\d+"`))},
+		},
+		{
+			name:     "body doesn't match",
+			path:     "/ok",
+			opts:     []HTTPCheckOption{WithHTTPBodyRegexp(regexp.MustCompile(`"version":"2\.`))},
+			expected: "response body doesn't match",
+		},
+		{
+			name:     "max latency exceeded",
+			path:     "/slow",
+			opts:     []HTTPCheckOption{WithHTTPMaxLatency(10 * time.Millisecond)},
+			expected: "exceeds 10ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HTTPCheck(server.URL+tt.path, tt.opts...)(context.Background())
+
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestHTTPCheck_TLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if err := HTTPCheck(server.URL)(context.Background()); err == nil {
+		t.Error("expected error for untrusted certificate")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	check := HTTPCheck(server.URL, WithHTTPTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+	if err := check(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPCheck_Target(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	checker := NewHealthChecker().
+		WithTarget("upstream", TargetImportanceHigh, HTTPCheck(server.URL))
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusFail || results[0].ErrorMessage != "unexpected status code 500" {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+}
@@ -0,0 +1,234 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidMaintenance is returned when scheduling a maintenance that ends
+// before it starts.
+var ErrInvalidMaintenance = errors.New("maintenance ends before it starts")
+
+// Maintenance describes a planned maintenance of a target or of the whole
+// checker. A zero Start means the maintenance starts right away, a zero Until
+// that it lasts until it is ended explicitly.
+type Maintenance struct {
+	Reason string    `json:"reason"`
+	Start  time.Time `json:"start,omitzero"`
+	Until  time.Time `json:"until,omitzero"`
+}
+
+// active reports whether the maintenance is in progress at the given time.
+func (m Maintenance) active(now time.Time) bool {
+	return (m.Start.IsZero() || !now.Before(m.Start)) && !m.expired(now)
+}
+
+// expired reports whether the maintenance is over at the given time.
+func (m Maintenance) expired(now time.Time) bool {
+	return !m.Until.IsZero() && !now.Before(m.Until)
+}
+
+// validate checks that the maintenance does not end before it starts.
+func (m Maintenance) validate() error {
+	if !m.Start.IsZero() && !m.Until.IsZero() && !m.Until.After(m.Start) {
+		return fmt.Errorf("maintenance from %s until %s: %w",
+			m.Start.Format(time.RFC3339), m.Until.Format(time.RFC3339), ErrInvalidMaintenance)
+	}
+
+	return nil
+}
+
+// StartMaintenance puts every target into maintenance with the given reason
+// until the given time, or until EndMaintenance is called if it is zero.
+// Targets under maintenance are still checked, but reported with the
+// maintenance status and left out of the overall status.
+func (c *HealthChecker) StartMaintenance(reason string, until time.Time) {
+	c.mu.Lock()
+	c.maintenance = &Maintenance{Reason: reason, Until: until}
+	c.mu.Unlock()
+}
+
+// ScheduleMaintenance puts every target into maintenance from m.Start until
+// m.Until, replacing any maintenance started or scheduled before. It returns
+// ErrInvalidMaintenance if the maintenance ends before it starts.
+func (c *HealthChecker) ScheduleMaintenance(m Maintenance) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.maintenance = &m
+	c.mu.Unlock()
+
+	return nil
+}
+
+// EndMaintenance ends or cancels the maintenance started with
+// StartMaintenance or ScheduleMaintenance. Targets put into maintenance with
+// StartTargetMaintenance remain in it.
+func (c *HealthChecker) EndMaintenance() {
+	c.mu.Lock()
+	c.maintenance = nil
+	c.mu.Unlock()
+}
+
+// Maintenance returns the maintenance of the checker in progress, if any.
+func (c *HealthChecker) Maintenance() (Maintenance, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.maintenance == nil || !c.maintenance.active(time.Now()) {
+		return Maintenance{}, false
+	}
+
+	return *c.maintenance, true
+}
+
+// ScheduledMaintenance returns the maintenance of the checker that is in
+// progress or yet to start, if any.
+func (c *HealthChecker) ScheduledMaintenance() (Maintenance, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.maintenance == nil || c.maintenance.expired(time.Now()) {
+		return Maintenance{}, false
+	}
+
+	return *c.maintenance, true
+}
+
+// StartTargetMaintenance puts the target with the given name into
+// maintenance with the given reason until the given time, or until
+// EndTargetMaintenance is called if it is zero.
+func (c *HealthChecker) StartTargetMaintenance(name, reason string, until time.Time) error {
+	return c.ScheduleTargetMaintenance(name, Maintenance{Reason: reason, Until: until})
+}
+
+// ScheduleTargetMaintenance puts the target with the given name into
+// maintenance from m.Start until m.Until, replacing any maintenance of the
+// target started or scheduled before.
+func (c *HealthChecker) ScheduleTargetMaintenance(name string, m Maintenance) error {
+	if err := m.validate(); err != nil {
+		return fmt.Errorf("scheduling maintenance of %s: %w", name, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(name) < 0 {
+		return fmt.Errorf("scheduling maintenance of %s: %w", name, ErrTargetNotFound)
+	}
+
+	if c.targetMaintenances == nil {
+		c.targetMaintenances = make(map[string]Maintenance)
+	}
+	c.targetMaintenances[name] = m
+
+	return nil
+}
+
+// EndTargetMaintenance ends or cancels the maintenance of the target with the
+// given name started with StartTargetMaintenance or
+// ScheduleTargetMaintenance.
+func (c *HealthChecker) EndTargetMaintenance(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(name) < 0 {
+		return fmt.Errorf("ending maintenance of %s: %w", name, ErrTargetNotFound)
+	}
+
+	delete(c.targetMaintenances, name)
+
+	return nil
+}
+
+// DisableTarget stops checking the target with the given name and leaves it
+// out of the results and metrics until EnableTarget is called.
+func (c *HealthChecker) DisableTarget(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(name) < 0 {
+		return fmt.Errorf("disabling target %s: %w", name, ErrTargetNotFound)
+	}
+
+	if c.disabled == nil {
+		c.disabled = make(map[string]bool)
+	}
+	c.disabled[name] = true
+	c.metrics.forget(name)
+
+	if c.runners != nil {
+		c.stopRunner(name)
+	}
+
+	return nil
+}
+
+// EnableTarget resumes checking the target with the given name disabled with
+// DisableTarget. When the checker is started, the target is checked in the
+// background right away.
+func (c *HealthChecker) EnableTarget(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.targetIndex(name)
+	if i < 0 {
+		return fmt.Errorf("enabling target %s: %w", name, ErrTargetNotFound)
+	}
+
+	if !c.disabled[name] {
+		return nil
+	}
+	delete(c.disabled, name)
+
+	if c.runners != nil {
+		c.startRunner(c.targets[i], true)
+	}
+
+	return nil
+}
+
+// targetMaintenance returns the active maintenance of the target with the
+// given name, either its own or the one of the whole checker. It must be
+// called with mu held.
+func (c *HealthChecker) targetMaintenance(name string) (Maintenance, bool) {
+	now := time.Now()
+
+	if m, ok := c.targetMaintenances[name]; ok && m.active(now) {
+		return m, true
+	}
+
+	if c.maintenance != nil && c.maintenance.active(now) {
+		return *c.maintenance, true
+	}
+
+	return Maintenance{}, false
+}
+
+// applyMaintenance reports the result with the maintenance status if its
+// target is under maintenance, keeping the status of the check in RawStatus.
+func (c *HealthChecker) applyMaintenance(result HealthCheckResult) HealthCheckResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.maintained(result)
+}
+
+// maintained is applyMaintenance for callers holding mu.
+func (c *HealthChecker) maintained(result HealthCheckResult) HealthCheckResult {
+	m, ok := c.targetMaintenance(result.Target.Name)
+	if !ok {
+		return result
+	}
+
+	if result.RawStatus == "" {
+		result.RawStatus = result.Status
+	}
+	result.Status = HealthTargetStatusMaintenance
+	result.Maintenance = &m
+
+	return result
+}
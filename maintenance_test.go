@@ -0,0 +1,323 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_TargetMaintenance(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	serve := func() (int, []HealthCheckResult) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		var results []HealthCheckResult
+		if err := json.NewDecoder(w.Body).Decode(&results); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		return w.Code, results
+	}
+
+	if code, _ := serve(); code != http.StatusInternalServerError {
+		t.Errorf("expected status %d before maintenance, got %d", http.StatusInternalServerError, code)
+	}
+
+	if err := checker.StartTargetMaintenance("queue", "upgrade", time.Time{}); !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	if err := checker.StartTargetMaintenance("database", "upgrade to 17", time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code, results := serve()
+	if code != http.StatusOK {
+		t.Errorf("expected status %d during maintenance, got %d", http.StatusOK, code)
+	}
+
+	database := results[0]
+	if database.Status != HealthTargetStatusMaintenance || database.RawStatus != HealthTargetStatusFail {
+		t.Errorf("expected maintenance status with raw fail status, got %s (%s)", database.Status, database.RawStatus)
+	}
+	if database.Maintenance == nil || database.Maintenance.Reason != "upgrade to 17" {
+		t.Errorf("expected maintenance reason, got %+v", database.Maintenance)
+	}
+	if database.ErrorMessage != "connection refused" {
+		t.Errorf("expected the check to still run, got error %q", database.ErrorMessage)
+	}
+	if results[1].Status != HealthTargetStatusOk {
+		t.Errorf("expected cache not to be under maintenance, got %s", results[1].Status)
+	}
+
+	if err := checker.EndTargetMaintenance("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if code, _ := serve(); code != http.StatusInternalServerError {
+		t.Errorf("expected status %d after maintenance, got %d", http.StatusInternalServerError, code)
+	}
+
+	if err := checker.StartTargetMaintenance("database", "upgrade to 17", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if code, _ := serve(); code != http.StatusInternalServerError {
+		t.Errorf("expected expired maintenance to be ignored, got %d", code)
+	}
+}
+
+func TestHealthChecker_Maintenance(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	if _, ok := checker.Maintenance(); ok {
+		t.Error("expected no maintenance")
+	}
+
+	until := time.Now().Add(time.Hour)
+	checker.StartMaintenance("datacenter move", until)
+
+	m, ok := checker.Maintenance()
+	if !ok || m.Reason != "datacenter move" || !m.Until.Equal(until) {
+		t.Errorf("expected maintenance, got %+v %v", m, ok)
+	}
+
+	results, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusMaintenance {
+		t.Errorf("expected maintenance status, got %s", results[0].Status)
+	}
+
+	if code := checker.statusCode(results); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+
+	checker.EndMaintenance()
+
+	if _, ok := checker.Maintenance(); ok {
+		t.Error("expected maintenance to end")
+	}
+}
+
+func TestHealthChecker_ScheduleMaintenance(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	now := time.Now()
+
+	err := checker.ScheduleTargetMaintenance("database", Maintenance{Reason: "upgrade", Start: now, Until: now})
+	if !errors.Is(err, ErrInvalidMaintenance) {
+		t.Errorf("expected ErrInvalidMaintenance, got %v", err)
+	}
+
+	if err := checker.ScheduleMaintenance(Maintenance{Reason: "upgrade", Start: now, Until: now.Add(-time.Hour)}); !errors.Is(err, ErrInvalidMaintenance) {
+		t.Errorf("expected ErrInvalidMaintenance, got %v", err)
+	}
+
+	if err := checker.ScheduleTargetMaintenance("queue", Maintenance{Reason: "upgrade"}); !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	upcoming := Maintenance{Reason: "upgrade to 17", Start: now.Add(time.Hour), Until: now.Add(2 * time.Hour)}
+	if err := checker.ScheduleTargetMaintenance("database", upcoming); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusFail {
+		t.Errorf("expected maintenance not to start before its start time, got %s", results[0].Status)
+	}
+
+	if info := checker.Targets()[0]; info.Maintenance == nil || !info.Maintenance.Start.Equal(upcoming.Start) {
+		t.Errorf("expected the scheduled maintenance to be listed, got %+v", info.Maintenance)
+	}
+
+	started := Maintenance{Reason: "upgrade to 17", Start: now.Add(-time.Minute), Until: now.Add(time.Hour)}
+	if err := checker.ScheduleTargetMaintenance("database", started); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err = checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Status != HealthTargetStatusMaintenance {
+		t.Errorf("expected maintenance to be in progress after its start time, got %s", results[0].Status)
+	}
+
+	if err := checker.ScheduleMaintenance(Maintenance{Reason: "datacenter move", Start: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := checker.Maintenance(); ok {
+		t.Error("expected checker maintenance not to be in progress")
+	}
+
+	if m, ok := checker.ScheduledMaintenance(); !ok || m.Reason != "datacenter move" {
+		t.Errorf("expected scheduled checker maintenance, got %+v %v", m, ok)
+	}
+}
+
+func TestHealthChecker_MaintenanceNotNotified(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	events := make(chan TransitionEvent, 10)
+
+	checker := NewHealthChecker(
+		WithNotifier(NotifierFunc(func(_ context.Context, event TransitionEvent) error {
+			events <- event
+			return nil
+		})),
+	).WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	check := func() {
+		t.Helper()
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	check()
+
+	checker.StartMaintenance("upgrade", time.Time{})
+	failing.Store(true)
+	check()
+	failing.Store(false)
+	check()
+	checker.EndMaintenance()
+	check()
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHealthChecker_DisableTarget(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			return errors.New("connection refused")
+		}).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil },
+			WithDependsOn("database"))
+
+	if err := checker.DisableTarget("queue"); !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls.Store(0)
+
+	if err := checker.DisableTarget("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Target.Name != "cache" || results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected only an ok cache result, got %+v", results)
+	}
+
+	if calls.Load() != 0 {
+		t.Errorf("expected disabled target not to be checked, got %d calls", calls.Load())
+	}
+
+	w := httptest.NewRecorder()
+	checker.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if body := w.Body.String(); strings.Contains(body, `name="database"`) {
+		t.Errorf("expected no metrics for the disabled target, got:\n%s", body)
+	}
+
+	if err := checker.EnableTarget("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err = checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 || results[1].Status != HealthTargetStatusSkipped {
+		t.Errorf("expected enabled target to be checked again, got %+v", results)
+	}
+}
+
+func TestPage_MaintenanceBanner(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil }).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	if err := checker.StartTargetMaintenance("cache", "flushing keys", time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checker.StartMaintenance("datacenter move", time.Time{})
+
+	w := httptest.NewRecorder()
+	NewPage(WithHealthChecker(checker)).Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := w.Body.String()
+
+	for _, expected := range []string{
+		"Scheduled maintenance in progress: datacenter move",
+		"Under maintenance: flushing keys",
+		`class="status-item maintenance"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected page to contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package status
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds of the check duration histogram
+// buckets in seconds.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// labelReplacer escapes label values of the Prometheus text format.
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metrics accumulates health check results as Prometheus metrics.
+type metrics struct {
+	mu      sync.Mutex
+	targets map[string]*targetMetrics
+}
+
+// targetMetrics are the metrics of a single target.
+type targetMetrics struct {
+	importance  TargetImportance
+	up          bool
+	buckets     []uint64
+	count       uint64
+	sum         float64
+	failures    uint64
+	lastSuccess time.Time
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		targets: make(map[string]*targetMetrics),
+	}
+}
+
+// observe records the result of a check before maintenance is applied to it.
+// Skipped results are not checks and are ignored.
+func (m *metrics) observe(result HealthCheckResult) {
+	if result.Status == HealthTargetStatusSkipped {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tm, ok := m.targets[result.Target.Name]
+	if !ok {
+		tm = &targetMetrics{
+			buckets: make([]uint64, len(durationBuckets)),
+		}
+		m.targets[result.Target.Name] = tm
+	}
+
+	seconds := result.Duration.Seconds()
+
+	tm.importance = result.Target.Importance
+	tm.up = result.Status == HealthTargetStatusOk || result.Status == HealthTargetStatusDegraded
+	tm.count++
+	tm.sum += seconds
+
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			tm.buckets[i]++
+		}
+	}
+
+	switch result.Status {
+	case HealthTargetStatusOk, HealthTargetStatusDegraded:
+		tm.lastSuccess = result.CheckedAt.Add(result.Duration)
+	case HealthTargetStatusFail:
+		tm.failures++
+	}
+}
+
+// forget drops the metrics of the target with the given name.
+func (m *metrics) forget(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.targets, name)
+}
+
+// write writes the metrics in the Prometheus text exposition format.
+func (m *metrics) write(w *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.targets))
+	for name := range m.targets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	labels := func(name string) string {
+		return fmt.Sprintf(`name="%s",importance="%s"`,
+			labelReplacer.Replace(name), labelReplacer.Replace(string(m.targets[name].importance)))
+	}
+
+	writeHeader(w, "status_target_up", "gauge", "Whether the health check target is healthy or degraded.")
+	for _, name := range names {
+		up := 0
+		if m.targets[name].up {
+			up = 1
+		}
+		fmt.Fprintf(w, "status_target_up{%s} %d\n", labels(name), up)
+	}
+
+	writeHeader(w, "status_target_check_duration_seconds", "histogram", "Duration of the health checks.")
+	for _, name := range names {
+		tm := m.targets[name]
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(w, "status_target_check_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels(name), formatFloat(bound), tm.buckets[i])
+		}
+		fmt.Fprintf(w, "status_target_check_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels(name), tm.count)
+		fmt.Fprintf(w, "status_target_check_duration_seconds_sum{%s} %s\n", labels(name), formatFloat(tm.sum))
+		fmt.Fprintf(w, "status_target_check_duration_seconds_count{%s} %d\n", labels(name), tm.count)
+	}
+
+	writeHeader(w, "status_target_failures_total", "counter", "Total number of failed health checks.")
+	for _, name := range names {
+		fmt.Fprintf(w, "status_target_failures_total{%s} %d\n", labels(name), m.targets[name].failures)
+	}
+
+	writeHeader(w, "status_target_last_success_timestamp_seconds", "gauge",
+		"Unix time of the last successful health check.")
+	for _, name := range names {
+		if lastSuccess := m.targets[name].lastSuccess; !lastSuccess.IsZero() {
+			fmt.Fprintf(w, "status_target_last_success_timestamp_seconds{%s} %s\n",
+				labels(name), formatFloat(float64(lastSuccess.UnixNano())/float64(time.Second)))
+		}
+	}
+}
+
+// MetricsHandler returns an HTTP handler that exposes the health check
+// results in the Prometheus text exposition format. Unless the checker is
+// started, every scrape runs the checks.
+func (c *HealthChecker) MetricsHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := c.Results(r.Context()); err != nil {
+			http.Error(w, fmt.Sprintf("Error checking health: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		bw := bufio.NewWriter(w)
+		c.metrics.write(bw)
+		if err := bw.Flush(); err != nil {
+			log.Printf("writing metrics: %v", err)
+		}
+	})
+}
+
+func writeHeader(w *bufio.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
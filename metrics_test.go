@@ -0,0 +1,108 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_MetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return nil
+		}).
+		WithTarget(`cache "eu"`, TargetImportanceLow, func(ctx context.Context) error {
+			return errors.New("cache miss")
+		})
+
+	var body string
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		w := httptest.NewRecorder()
+
+		checker.MetricsHandler().ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+			t.Errorf("unexpected content type %s", contentType)
+		}
+
+		body = w.Body.String()
+	}
+
+	expected := []string{
+		"# TYPE status_target_up gauge",
+		`status_target_up{name="database",importance="high"} 1`,
+		`status_target_up{name="cache \"eu\"",importance="low"} 0`,
+		"# TYPE status_target_check_duration_seconds histogram",
+		`status_target_check_duration_seconds_bucket{name="database",importance="high",le="10"} 2`,
+		`status_target_check_duration_seconds_bucket{name="database",importance="high",le="+Inf"} 2`,
+		`status_target_check_duration_seconds_count{name="database",importance="high"} 2`,
+		"# TYPE status_target_failures_total counter",
+		`status_target_failures_total{name="database",importance="high"} 0`,
+		`status_target_failures_total{name="cache \"eu\"",importance="low"} 2`,
+		"# TYPE status_target_last_success_timestamp_seconds gauge",
+		`status_target_last_success_timestamp_seconds{name="database",importance="high"} `,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+
+	if strings.Contains(body, `status_target_last_success_timestamp_seconds{name="cache`) {
+		t.Errorf("expected no last success timestamp for never healthy target, got:\n%s", body)
+	}
+}
+
+func TestHealthChecker_MetricsSkippedAndMaintenance(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil },
+			WithDependsOn("database")).
+		WithTarget("queue", TargetImportanceLow, func(ctx context.Context) error { return nil }).
+		WithTarget("search", TargetImportanceLow, func(ctx context.Context) error {
+			return errors.New("index unavailable")
+		}, WithFlapDamping(3, 1))
+
+	for _, name := range []string{"database", "queue", "search"} {
+		if err := checker.StartTargetMaintenance(name, "upgrade", time.Time{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	checker.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+
+	for _, line := range []string{
+		`status_target_up{name="database",importance="high"} 0`,
+		`status_target_failures_total{name="database",importance="high"} 1`,
+		`status_target_up{name="queue",importance="low"} 1`,
+		`status_target_up{name="search",importance="low"} 1`,
+		`status_target_failures_total{name="search",importance="low"} 0`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+
+	if strings.Contains(body, `name="cache"`) {
+		t.Errorf("expected no metrics for the skipped target, got:\n%s", body)
+	}
+}
@@ -0,0 +1,110 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+)
+
+// TCPCheckOption is a function that configures TCPCheck.
+type TCPCheckOption func(*net.Dialer)
+
+// WithTCPDialer sets the dialer opening the connection. Its settings are
+// copied, so it can be configured further with other options.
+func WithTCPDialer(dialer *net.Dialer) TCPCheckOption {
+	return func(d *net.Dialer) {
+		*d = *dialer
+	}
+}
+
+// WithTCPResolver sets the resolver of the host of the address.
+func WithTCPResolver(resolver *net.Resolver) TCPCheckOption {
+	return func(d *net.Dialer) {
+		d.Resolver = resolver
+	}
+}
+
+// TCPCheck returns a HealthCheckFunc that opens a TCP connection to the
+// address in the host:port form and closes it.
+func TCPCheck(addr string, opts ...TCPCheckOption) HealthCheckFunc {
+	var dialer net.Dialer
+
+	for _, opt := range opts {
+		opt(&dialer)
+	}
+
+	return func(ctx context.Context) error {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("dialing %s: %w", addr, err)
+		}
+
+		if err := conn.Close(); err != nil {
+			return fmt.Errorf("closing connection to %s: %w", addr, err)
+		}
+
+		return nil
+	}
+}
+
+// DNSCheckOption is a function that configures DNSCheck.
+type DNSCheckOption func(*dnsCheck)
+
+// WithDNSResolver sets the resolver looking up the name. Defaults to
+// net.DefaultResolver.
+func WithDNSResolver(resolver *net.Resolver) DNSCheckOption {
+	return func(c *dnsCheck) {
+		c.resolver = resolver
+	}
+}
+
+// WithDNSExpectedAddrs requires the name to resolve to all of the given
+// addresses.
+func WithDNSExpectedAddrs(addrs ...string) DNSCheckOption {
+	return func(c *dnsCheck) {
+		c.addrs = append(c.addrs, addrs...)
+	}
+}
+
+// dnsCheck is the configuration of DNSCheck.
+type dnsCheck struct {
+	name     string
+	resolver *net.Resolver
+	addrs    []string
+}
+
+// DNSCheck returns a HealthCheckFunc that checks that the host name
+// resolves to at least one address.
+func DNSCheck(name string, opts ...DNSCheckOption) HealthCheckFunc {
+	c := &dnsCheck{
+		name:     name,
+		resolver: net.DefaultResolver,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c.check
+}
+
+func (c *dnsCheck) check(ctx context.Context) error {
+	addrs, err := c.resolver.LookupHost(ctx, c.name)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", c.name, err)
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("resolving %s: %w", c.name, errors.New("no addresses"))
+	}
+
+	for _, addr := range c.addrs {
+		if !slices.Contains(addrs, addr) {
+			return fmt.Errorf("%s doesn't resolve to %s", c.name, addr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package status
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTCPCheck(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().String()
+	check := TCPCheck(addr, WithTCPDialer(&net.Dialer{KeepAlive: -1}))
+
+	if err := check(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	listener.Close()
+
+	if err := check(context.Background()); err == nil || !strings.Contains(err.Error(), "dialing "+addr) {
+		t.Errorf("expected dialing error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := TCPCheck("127.0.0.1:1")(ctx); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}
+
+func TestDNSCheck(t *testing.T) {
+	t.Parallel()
+
+	resolver := fakeResolver(t, map[string]net.IP{
+		"db.test.": net.IPv4(10, 0, 0, 1),
+	})
+
+	tests := []struct {
+		name     string
+		host     string
+		opts     []DNSCheckOption
+		expected string
+	}{
+		{
+			name: "resolves",
+			host: "db.test.",
+		},
+		{
+			name: "resolves to expected address",
+			host: "db.test.",
+			opts: []DNSCheckOption{WithDNSExpectedAddrs("10.0.0.1")},
+		},
+		{
+			name:     "doesn't resolve to expected address",
+			host:     "db.test.",
+			opts:     []DNSCheckOption{WithDNSExpectedAddrs("10.0.0.2")},
+			expected: "db.test. doesn't resolve to 10.0.0.2",
+		},
+		{
+			name:     "doesn't resolve",
+			host:     "unknown.test.",
+			expected: "resolving unknown.test.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := DNSCheck(tt.host, append([]DNSCheckOption{WithDNSResolver(resolver)}, tt.opts...)...)(ctx)
+
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// fakeResolver starts a DNS server answering A queries for the given names
+// and returns a resolver using it.
+func fakeResolver(t *testing.T, records map[string]net.IP) *net.Resolver {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if resp := fakeDNSResponse(buf[:n], records); resp != nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+}
+
+// fakeDNSResponse builds a response to the DNS query with a single question.
+func fakeDNSResponse(query []byte, records map[string]net.IP) []byte {
+	const headerSize = 12
+
+	if len(query) < headerSize {
+		return nil
+	}
+
+	// Read the question name to find the end of the question section.
+	var labels []string
+	i := headerSize
+	for i < len(query) && query[i] != 0 {
+		size := int(query[i])
+		if i+1+size > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[i+1:i+1+size]))
+		i += 1 + size
+	}
+
+	questionEnd := i + 5
+	if questionEnd > len(query) {
+		return nil
+	}
+
+	name := strings.Join(labels, ".") + "."
+	qtype := binary.BigEndian.Uint16(query[i+1 : i+3])
+	ip, known := records[name]
+
+	resp := make([]byte, 0, 512)
+	resp = append(resp, query[0:2]...)
+
+	switch {
+	case !known:
+		resp = append(resp, 0x81, 0x83, 0, 1, 0, 0, 0, 0, 0, 0)
+	case qtype != 1:
+		resp = append(resp, 0x81, 0x80, 0, 1, 0, 0, 0, 0, 0, 0)
+	default:
+		resp = append(resp, 0x81, 0x80, 0, 1, 0, 1, 0, 0, 0, 0)
+	}
+
+	resp = append(resp, query[headerSize:questionEnd]...)
+
+	if known && qtype == 1 {
+		resp = append(resp, 0xc0, headerSize, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+		resp = append(resp, ip.To4()...)
+	}
+
+	return resp
+}
@@ -0,0 +1,196 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// TransitionEvent describes a change of the status of a target.
+type TransitionEvent struct {
+	Target    HealthTarget       `json:"target"`
+	OldStatus HealthTargetStatus `json:"old_status"`
+	NewStatus HealthTargetStatus `json:"new_status"`
+	Error     string             `json:"error,omitempty"`
+	Time      time.Time          `json:"time"`
+}
+
+// Notifier is notified when a target transitions between ok, degraded and
+// fail statuses.
+type Notifier interface {
+	Notify(ctx context.Context, event TransitionEvent) error
+}
+
+// NotifierFunc is an adapter to use ordinary functions as a Notifier.
+type NotifierFunc func(ctx context.Context, event TransitionEvent) error
+
+// Notify calls f(ctx, event).
+func (f NotifierFunc) Notify(ctx context.Context, event TransitionEvent) error {
+	return f(ctx, event)
+}
+
+// WithNotifier adds a notifier of the status transitions of the targets.
+// Notifiers are called asynchronously, so they don't delay the checks, but
+// each notifier is called with one event at a time, in the order of the
+// transitions. Stop waits for the pending notifications.
+func WithNotifier(notifier Notifier) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.notifiers = append(c.notifiers, newNotifierQueue(notifier))
+	}
+}
+
+// notification is a transition event waiting to be delivered.
+type notification struct {
+	ctx   context.Context
+	event TransitionEvent
+}
+
+// notifierQueue delivers notifications to a notifier in order, from a single
+// worker that runs while notifications are pending.
+type notifierQueue struct {
+	notifier Notifier
+
+	mu      sync.Mutex
+	idle    *sync.Cond
+	pending []notification
+	running bool
+}
+
+func newNotifierQueue(notifier Notifier) *notifierQueue {
+	q := &notifierQueue{notifier: notifier}
+	q.idle = sync.NewCond(&q.mu)
+
+	return q
+}
+
+// push queues the notification, starting the worker if it isn't running.
+func (q *notifierQueue) push(n notification) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, n)
+
+	if !q.running {
+		q.running = true
+		go q.drain()
+	}
+}
+
+// drain delivers the pending notifications until none is left.
+func (q *notifierQueue) drain() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.idle.Broadcast()
+			q.mu.Unlock()
+			return
+		}
+		n := q.pending[0]
+		q.pending[0] = notification{}
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		if err := q.notifier.Notify(n.ctx, n.event); err != nil {
+			log.Printf("notifying %s transition from %s to %s: %v",
+				n.event.Target.Name, n.event.OldStatus, n.event.NewStatus, err)
+		}
+	}
+}
+
+// wait waits until no notification is pending.
+func (q *notifierQueue) wait() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.running {
+		q.idle.Wait()
+	}
+}
+
+// waitNotifications waits for the pending notifications of all notifiers.
+func (c *HealthChecker) waitNotifications() {
+	for _, q := range c.notifiers {
+		q.wait()
+	}
+}
+
+// MuteTarget stops notifying the notifiers of the status transitions of the
+// target with the given name until UnmuteTarget is called. The target is
+// still checked and reported.
+func (c *HealthChecker) MuteTarget(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(name) < 0 {
+		return fmt.Errorf("muting target %s: %w", name, ErrTargetNotFound)
+	}
+
+	if c.muted == nil {
+		c.muted = make(map[string]bool)
+	}
+	c.muted[name] = true
+
+	return nil
+}
+
+// UnmuteTarget resumes notifying the status transitions of the target with
+// the given name muted with MuteTarget.
+func (c *HealthChecker) UnmuteTarget(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(name) < 0 {
+		return fmt.Errorf("unmuting target %s: %w", name, ErrTargetNotFound)
+	}
+
+	delete(c.muted, name)
+
+	return nil
+}
+
+// notify notifies the notifiers if the status of the target of the result
+// differs from the previous one. The first result of a target, skipped
+// results, results under maintenance and results of muted targets are not
+// notified. It must be called with mu held.
+func (c *HealthChecker) notify(ctx context.Context, result HealthCheckResult) {
+	if len(c.notifiers) == 0 ||
+		result.Status == HealthTargetStatusSkipped ||
+		result.Status == HealthTargetStatusMaintenance {
+		return
+	}
+
+	if c.muted[result.Target.Name] {
+		return
+	}
+
+	// Queueing with statesMu held keeps the events of concurrent checks of
+	// the target in the order of the transitions.
+	c.statesMu.Lock()
+	defer c.statesMu.Unlock()
+
+	state := c.state(result.Target.Name)
+	previous := state.notifiedStatus
+	state.notifiedStatus = result.Status
+
+	if previous == "" || previous == result.Status {
+		return
+	}
+
+	n := notification{
+		ctx: context.WithoutCancel(ctx),
+		event: TransitionEvent{
+			Target:    result.Target,
+			OldStatus: previous,
+			NewStatus: result.Status,
+			Error:     result.ErrorMessage,
+			Time:      result.CheckedAt,
+		},
+	}
+
+	for _, q := range c.notifiers {
+		q.push(n)
+	}
+}
@@ -0,0 +1,199 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_Notify(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	events := make(chan TransitionEvent, 10)
+
+	checker := NewHealthChecker(
+		WithNotifier(NotifierFunc(func(_ context.Context, event TransitionEvent) error {
+			events <- event
+			return nil
+		})),
+	).WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	steps := []struct {
+		failing       bool
+		expectedEvent *TransitionEvent
+	}{
+		{false, nil},
+		{false, nil},
+		{true, &TransitionEvent{OldStatus: HealthTargetStatusOk, NewStatus: HealthTargetStatusFail, Error: "connection refused"}},
+		{true, nil},
+		{false, &TransitionEvent{OldStatus: HealthTargetStatusFail, NewStatus: HealthTargetStatusOk}},
+	}
+
+	for i, step := range steps {
+		failing.Store(step.failing)
+
+		results, err := checker.Check(context.Background())
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+
+		if step.expectedEvent == nil {
+			select {
+			case event := <-events:
+				t.Fatalf("step %d: unexpected event %+v", i, event)
+			case <-time.After(50 * time.Millisecond):
+			}
+			continue
+		}
+
+		select {
+		case event := <-events:
+			if event.Target.Name != "database" {
+				t.Errorf("step %d: expected target database, got %s", i, event.Target.Name)
+			}
+			if event.OldStatus != step.expectedEvent.OldStatus || event.NewStatus != step.expectedEvent.NewStatus {
+				t.Errorf("step %d: expected transition %s -> %s, got %s -> %s", i,
+					step.expectedEvent.OldStatus, step.expectedEvent.NewStatus, event.OldStatus, event.NewStatus)
+			}
+			if event.Error != step.expectedEvent.Error {
+				t.Errorf("step %d: expected error %q, got %q", i, step.expectedEvent.Error, event.Error)
+			}
+			if !event.Time.Equal(results[0].CheckedAt) {
+				t.Errorf("step %d: expected time %v, got %v", i, results[0].CheckedAt, event.Time)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("step %d: expected event", i)
+		}
+	}
+}
+
+func TestHealthChecker_NotifyAbortedCheck(t *testing.T) {
+	t.Parallel()
+
+	var hanging atomic.Bool
+
+	events := make(chan TransitionEvent, 10)
+
+	checker := NewHealthChecker(
+		WithRunTimeout(20*time.Millisecond),
+		WithHistory(10, 0),
+		WithNotifier(NotifierFunc(func(_ context.Context, event TransitionEvent) error {
+			events <- event
+			return nil
+		})),
+	).WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+		if hanging.Load() {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	if _, err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hanging.Store(true)
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Status != HealthTargetStatusFail {
+		t.Errorf("expected the aborted check to fail, got %s", results[0].Status)
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	history, err := checker.History(context.Background(), "database")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(history.Results) != 1 || history.Uptime != 100 {
+		t.Errorf("expected the aborted check not to be recorded, got %+v", history)
+	}
+
+	w := httptest.NewRecorder()
+	checker.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(w.Body.String(), `status_target_failures_total{name="database",importance="high"} 0`) {
+		t.Errorf("expected the aborted check not to count as a failure, got %s", w.Body.String())
+	}
+}
+
+func TestHealthChecker_NotifyOrder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		failing  atomic.Bool
+		mu       sync.Mutex
+		received []HealthTargetStatus
+	)
+
+	release := make(chan struct{})
+
+	checker := NewHealthChecker(
+		WithNotifier(NotifierFunc(func(_ context.Context, event TransitionEvent) error {
+			<-release
+
+			mu.Lock()
+			received = append(received, event.NewStatus)
+			mu.Unlock()
+
+			return nil
+		})),
+	).WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	for _, fail := range []bool{false, true, false, true} {
+		failing.Store(fail)
+
+		if _, err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		checker.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("expected Stop to wait for the pending notifications")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []HealthTargetStatus{HealthTargetStatusFail, HealthTargetStatusOk, HealthTargetStatusFail}
+	if !slices.Equal(received, expected) {
+		t.Errorf("expected notifications %v in order, got %v", expected, received)
+	}
+}
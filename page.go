@@ -6,6 +6,7 @@ import (
 	"html/template"
 	"net/http"
 	"runtime/debug"
+	"time"
 )
 
 var (
@@ -21,6 +22,10 @@ type Page struct {
 	hc          *HealthChecker
 	links       []Link
 	showVersion bool
+
+	announcements     Announcements
+	announcementsFile string
+	incidentRetention time.Duration
 }
 
 // PageOption is a function that configures a Page
@@ -70,6 +75,8 @@ func NewPage(opts ...PageOption) *Page {
 		title:       "System Status",
 		tmpl:        defaultTemplate,
 		showVersion: true,
+
+		incidentRetention: defaultIncidentRetention,
 	}
 
 	for _, opt := range opts {
@@ -85,12 +92,66 @@ type Link struct {
 	URL  string
 }
 
-// PageData contains the data that will be rendered in the status page template
+// PageData contains the data that will be rendered in the status page
+// template. It is also responded as JSON to clients accepting
+// application/json.
 type PageData struct {
-	Title         string
-	Version       string
-	HealthResults []HealthCheckResult
-	Links         []Link
+	Title         string                   `json:"title"`
+	Version       string                   `json:"version,omitempty"`
+	HealthResults []HealthCheckResult      `json:"results"`
+	History       map[string]TargetHistory `json:"history,omitempty"`
+	Groups        []GroupResult            `json:"-"`
+	Links         []Link                   `json:"-"`
+	Maintenance   *Maintenance             `json:"maintenance,omitempty"`
+	Now           time.Time                `json:"-"`
+
+	Announcements
+}
+
+// GroupResult contains the results of the targets of a group. Targets without
+// a group belong to the group with an empty name.
+type GroupResult struct {
+	Name    string
+	Status  HealthTargetStatus
+	Results []TargetResult
+}
+
+// TargetResult is a health check result along with the history of its target
+type TargetResult struct {
+	HealthCheckResult
+	History TargetHistory
+}
+
+// groupResults groups the results by target group in order of appearance.
+// The status of a group follows the same importance rules as the overall
+// status.
+func groupResults(results []HealthCheckResult, history map[string]TargetHistory) []GroupResult {
+	var groups []GroupResult
+	indices := make(map[string]int)
+	grouped := make(map[string][]HealthCheckResult)
+
+	for _, result := range results {
+		name := result.Target.Group
+
+		i, ok := indices[name]
+		if !ok {
+			i = len(groups)
+			indices[name] = i
+			groups = append(groups, GroupResult{Name: name})
+		}
+
+		groups[i].Results = append(groups[i].Results, TargetResult{
+			HealthCheckResult: result,
+			History:           history[result.Target.Name],
+		})
+		grouped[name] = append(grouped[name], result)
+	}
+
+	for i := range groups {
+		groups[i].Status = overallStatus(grouped[groups[i].Name])
+	}
+
+	return groups
 }
 
 // Handler returns an HTTP handler that serves the status page
@@ -101,7 +162,7 @@ func (p *Page) Handler() http.HandlerFunc {
 		var healthResults []HealthCheckResult
 		if p.hc != nil {
 			var err error
-			healthResults, err = p.hc.Check(r.Context())
+			healthResults, err = p.hc.Results(r.Context())
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Error checking health: %v", err), http.StatusInternalServerError)
 				return
@@ -114,10 +175,36 @@ func (p *Page) Handler() http.HandlerFunc {
 			Links:         p.links,
 		}
 
+		if p.hc != nil {
+			var err error
+			data.History, err = p.hc.Histories(r.Context())
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Error retrieving history: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		data.Groups = groupResults(healthResults, data.History)
+
+		if p.hc != nil {
+			if m, ok := p.hc.Maintenance(); ok {
+				data.Maintenance = &m
+			}
+		}
+
+		data.Now = time.Now()
+
+		data.Announcements = p.currentAnnouncements(data.Now)
+
 		if p.showVersion {
 			data.Version = version
 		}
 
+		if accepts(r, "application/json") {
+			respondJSON(w, http.StatusOK, data)
+			return
+		}
+
 		w.Header().Set("Content-Type", "text/html")
 		if err := p.tmpl.Execute(w, data); err != nil {
 			http.Error(w, fmt.Sprintf("Error executing template :%v", err), http.StatusInternalServerError)
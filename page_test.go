@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestPage_Handler(t *testing.T) {
@@ -107,6 +108,116 @@ func TestPage_Handler(t *testing.T) {
 				"Warning: cache miss",
 			},
 		},
+		{
+			name: "page with history",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker(WithHistory(90, 0)).
+					WithTarget("Database", TargetImportanceHigh, func(ctx context.Context) error {
+						return nil
+					})),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<div class="history-bar">`,
+				`<span class="history-entry ok"`,
+				"Uptime: 100.00%",
+			},
+		},
+		{
+			name: "page with groups",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Postgres", TargetImportanceHigh, func(ctx context.Context) error {
+						return errors.New("connection refused")
+					}, WithGroup("Databases")).
+					WithTarget("Kafka", TargetImportanceLow, func(ctx context.Context) error {
+						return errors.New("broker unavailable")
+					}, WithGroup("Queues"))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<details class="status-group fail" open>`,
+				`<span class="group-name">Databases</span>`,
+				`<details class="status-group ok" open>`,
+				`<span class="group-name">Queues</span>`,
+				"<h3>Kafka</h3>",
+			},
+		},
+		{
+			name: "page with skipped dependent",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Database", TargetImportanceHigh, func(ctx context.Context) error {
+						return errors.New("connection refused")
+					}).
+					WithTarget("Sessions", TargetImportanceHigh, func(ctx context.Context) error {
+						return nil
+					}, WithDependsOn("Database"))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<div class="status-item skipped">`,
+				"Status: <strong>skipped</strong>",
+				"Skipped because Database is unhealthy",
+			},
+		},
+		{
+			name: "page with detailed health check",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithDetailedTarget("Replica", TargetImportanceHigh, func(ctx context.Context) (CheckReport, error) {
+						return CheckReport{
+							Details:       map[string]any{"host": "replica-1"},
+							ObservedValue: 340,
+							ObservedUnit:  "ms",
+							Measurement:   "replicationLag",
+							Output:        "replicating",
+						}, nil
+					})),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<p class="output">replicating</p>`,
+				"replicationLag: <strong>340 ms</strong>",
+				"<dt>host</dt><dd>replica-1</dd>",
+			},
+		},
+		{
+			name: "page with degraded health check",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Database", TargetImportanceHigh, func(ctx context.Context) error {
+						return Degraded(errors.New("responding slowly"))
+					}, WithGroup("Databases"))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<details class="status-group degraded" open>`,
+				`<div class="status-item warning">`,
+				"Status: <strong>degraded</strong>",
+				"Warning: responding slowly",
+			},
+		},
+		{
+			name: "page with latency budget",
+			page: NewPage(
+				WithTitle("Test Status"),
+				WithHealthChecker(NewHealthChecker().
+					WithTarget("Database", TargetImportanceHigh, func(ctx context.Context) error {
+						time.Sleep(time.Millisecond)
+						return nil
+					}, WithLatencyThresholds(500*time.Millisecond, time.Second))),
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				" / 500ms budget",
+			},
+		},
 		{
 			name: "page with version info",
 			page: NewPage(
@@ -152,3 +263,52 @@ func TestPage_Handler(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupResults(t *testing.T) {
+	t.Parallel()
+
+	results := []HealthCheckResult{
+		{Target: HealthTarget{Name: "api", Importance: TargetImportanceHigh}, Status: HealthTargetStatusOk},
+		{Target: HealthTarget{Name: "postgres", Importance: TargetImportanceHigh, Group: "Databases"}, Status: HealthTargetStatusOk},
+		{Target: HealthTarget{Name: "kafka", Importance: TargetImportanceLow, Group: "Queues"}, Status: HealthTargetStatusFail},
+		{Target: HealthTarget{Name: "redis", Importance: TargetImportanceHigh, Group: "Databases"}, Status: HealthTargetStatusFail},
+	}
+	history := map[string]TargetHistory{"postgres": {Uptime: 99}}
+
+	groups := groupResults(results, history)
+
+	expected := []struct {
+		name    string
+		status  HealthTargetStatus
+		targets []string
+	}{
+		{"", HealthTargetStatusOk, []string{"api"}},
+		{"Databases", HealthTargetStatusFail, []string{"postgres", "redis"}},
+		{"Queues", HealthTargetStatusOk, []string{"kafka"}},
+	}
+
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %d", len(expected), len(groups))
+	}
+
+	for i, group := range groups {
+		if group.Name != expected[i].name || group.Status != expected[i].status {
+			t.Errorf("group[%d]: expected %q %s, got %q %s", i, expected[i].name, expected[i].status, group.Name, group.Status)
+		}
+
+		if len(group.Results) != len(expected[i].targets) {
+			t.Errorf("group[%d]: expected %d results, got %d", i, len(expected[i].targets), len(group.Results))
+			continue
+		}
+
+		for j, result := range group.Results {
+			if result.Target.Name != expected[i].targets[j] {
+				t.Errorf("group[%d] result[%d]: expected target %s, got %s", i, j, expected[i].targets[j], result.Target.Name)
+			}
+		}
+	}
+
+	if groups[1].Results[0].History.Uptime != 99 {
+		t.Errorf("expected result to carry its history, got %+v", groups[1].Results[0].History)
+	}
+}
@@ -0,0 +1,51 @@
+package status
+
+import (
+	"fmt"
+	"log"
+)
+
+// maxPanicStackSize is the maximum size of the stack trace of a panicked
+// check included in the details of its result.
+const maxPanicStackSize = 4 << 10
+
+// PanicHandler is called when a check panics with the name of its target,
+// the recovered value and the stack trace of the panic.
+type PanicHandler func(target string, recovered any, stack []byte)
+
+// WithPanicHandler sets the handler called when a check panics, e.g. to
+// report the panic. By default the panic is logged.
+func WithPanicHandler(handler PanicHandler) HealthCheckerOption {
+	return func(c *HealthChecker) {
+		c.panicHandler = handler
+	}
+}
+
+// panicError is the error of a check that panicked.
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// truncatedStack returns the stack trace limited to maxPanicStackSize.
+func (e *panicError) truncatedStack() string {
+	if len(e.stack) <= maxPanicStackSize {
+		return string(e.stack)
+	}
+
+	return string(e.stack[:maxPanicStackSize]) + "\n..."
+}
+
+// onPanic calls the panic handler or logs the panic.
+func (c *HealthChecker) onPanic(target string, recovered any, stack []byte) {
+	if c.panicHandler != nil {
+		c.panicHandler(target, recovered, stack)
+		return
+	}
+
+	log.Printf("health check of %s panicked: %v\n%s", target, recovered, stack)
+}
@@ -0,0 +1,81 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHealthChecker_Panic(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var panicked []string
+
+	checker := NewHealthChecker(WithPanicHandler(func(target string, recovered any, stack []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(stack) == 0 {
+			t.Error("expected stack trace to be passed to the handler")
+		}
+		panicked = append(panicked, target+": "+recovered.(string))
+	})).
+		WithTarget("buggy", TargetImportanceHigh, func(ctx context.Context) error {
+			panic("nil map write")
+		}, WithRetry(RetryPolicy{Attempts: 3})).
+		WithDetailedTarget("buggy detailed", TargetImportanceLow, func(ctx context.Context) (CheckReport, error) {
+			panic("index out of range")
+		}).
+		WithTarget("healthy", TargetImportanceLow, func(ctx context.Context) error {
+			return nil
+		})
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"panic: nil map write", "panic: index out of range", ""}
+
+	for i, result := range results {
+		if result.ErrorMessage != expected[i] {
+			t.Errorf("result[%d]: expected error %q, got %q", i, expected[i], result.ErrorMessage)
+		}
+
+		if expected[i] == "" {
+			continue
+		}
+
+		if result.Status != HealthTargetStatusFail {
+			t.Errorf("result[%d]: expected status %s, got %s", i, HealthTargetStatusFail, result.Status)
+		}
+
+		stack, _ := result.Details["panic_stack"].(string)
+		if !strings.Contains(stack, "panic") || len(stack) > maxPanicStackSize+len("\n...") {
+			t.Errorf("result[%d]: expected truncated stack trace in details, got %q", i, stack)
+		}
+	}
+
+	if results[0].Attempts != 1 {
+		t.Errorf("expected panicked check not to be retried, got %d attempts", results[0].Attempts)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(panicked) != 2 {
+		t.Errorf("expected handler to be called twice, got %v", panicked)
+	}
+}
+
+func TestPanicError_TruncatedStack(t *testing.T) {
+	t.Parallel()
+
+	err := &panicError{value: "boom", stack: []byte(strings.Repeat("a", maxPanicStackSize+100))}
+
+	if stack := err.truncatedStack(); len(stack) != maxPanicStackSize+len("\n...") {
+		t.Errorf("expected stack to be truncated, got %d bytes", len(stack))
+	}
+}
@@ -0,0 +1,116 @@
+package status
+
+import (
+	"net/http"
+	"slices"
+)
+
+// ProbeKind identifies a kind of Kubernetes-style probe.
+type ProbeKind string
+
+const (
+	// ProbeLiveness tells whether the application is running and doesn't need
+	// a restart.
+	ProbeLiveness = ProbeKind("liveness")
+	// ProbeReadiness tells whether the application is ready to receive traffic.
+	ProbeReadiness = ProbeKind("readiness")
+	// ProbeStartup tells whether the application has finished starting up.
+	ProbeStartup = ProbeKind("startup")
+)
+
+// WithProbes sets the kinds of probes the target participates in. Targets
+// without explicit probes participate in readiness and startup probes only.
+func WithProbes(kinds ...ProbeKind) TargetOption {
+	return func(t *HealthTarget) {
+		t.probes = kinds
+	}
+}
+
+// participates reports whether the target is checked by the probe of kind.
+func (t HealthTarget) participates(kind ProbeKind) bool {
+	if len(t.probes) == 0 {
+		return kind != ProbeLiveness
+	}
+
+	return slices.Contains(t.probes, kind)
+}
+
+// LivenessHandler returns an HTTP handler serving the liveness probe. It
+// checks only the targets participating in liveness probes, so with none of
+// them it always responds healthy.
+func (c *HealthChecker) LivenessHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.respondProbe(w, r, ProbeLiveness)
+	})
+}
+
+// ReadinessHandler returns an HTTP handler serving the readiness probe. The
+// result of the checks can be overridden with SetReadiness.
+func (c *HealthChecker) ReadinessHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		readiness := c.readiness
+		c.mu.RUnlock()
+
+		switch {
+		case readiness == nil:
+			c.respondProbe(w, r, ProbeReadiness)
+		case *readiness:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// StartupHandler returns an HTTP handler serving the startup probe. Once the
+// startup targets are healthy, it keeps responding healthy without running
+// the checks again.
+func (c *HealthChecker) StartupHandler() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		startedUp := c.startedUp
+		c.mu.RUnlock()
+
+		if startedUp {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if c.respondProbe(w, r, ProbeStartup) == http.StatusOK {
+			c.mu.Lock()
+			c.startedUp = true
+			c.mu.Unlock()
+		}
+	})
+}
+
+// SetReadiness overrides the readiness probe, e.g. to drain traffic during
+// graceful shutdown. The probe then responds according to ready without
+// running the checks until ResetReadiness is called.
+func (c *HealthChecker) SetReadiness(ready bool) {
+	c.mu.Lock()
+	c.readiness = &ready
+	c.mu.Unlock()
+}
+
+// ResetReadiness removes the override set by SetReadiness.
+func (c *HealthChecker) ResetReadiness() {
+	c.mu.Lock()
+	c.readiness = nil
+	c.mu.Unlock()
+}
+
+// respondProbe responds with the results of the targets participating in
+// the probe of kind and returns the status code written.
+func (c *HealthChecker) respondProbe(w http.ResponseWriter, r *http.Request, kind ProbeKind) int {
+	results, err := c.results(r.Context(), func(t HealthTarget) bool {
+		return t.participates(kind)
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, err)
+		return http.StatusInternalServerError
+	}
+
+	return c.respondResults(w, r, results)
+}
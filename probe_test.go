@@ -0,0 +1,163 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHealthChecker_ProbeHandlers(t *testing.T) {
+	t.Parallel()
+
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("unavailable") }
+
+	tests := []struct {
+		name            string
+		checker         *HealthChecker
+		handler         func(*HealthChecker) http.HandlerFunc
+		expectedStatus  int
+		expectedTargets []string
+	}{
+		{
+			name: "liveness without liveness targets is healthy",
+			checker: NewHealthChecker().
+				WithTarget("database", TargetImportanceHigh, fail),
+			handler:         (*HealthChecker).LivenessHandler,
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{},
+		},
+		{
+			name: "liveness checks only liveness targets",
+			checker: NewHealthChecker().
+				WithTarget("database", TargetImportanceHigh, fail).
+				WithTarget("deadlock", TargetImportanceHigh, fail, WithProbes(ProbeLiveness)),
+			handler:         (*HealthChecker).LivenessHandler,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedTargets: []string{"deadlock"},
+		},
+		{
+			name: "readiness checks untagged targets",
+			checker: NewHealthChecker().
+				WithTarget("database", TargetImportanceHigh, ok).
+				WithTarget("deadlock", TargetImportanceHigh, fail, WithProbes(ProbeLiveness)),
+			handler:         (*HealthChecker).ReadinessHandler,
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{"database"},
+		},
+		{
+			name: "startup checks startup targets",
+			checker: NewHealthChecker().
+				WithTarget("migrations", TargetImportanceHigh, fail, WithProbes(ProbeStartup)).
+				WithTarget("cache", TargetImportanceHigh, ok, WithProbes(ProbeReadiness)),
+			handler:         (*HealthChecker).StartupHandler,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedTargets: []string{"migrations"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+
+			tt.handler(tt.checker).ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var response []HealthCheckResult
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if len(response) != len(tt.expectedTargets) {
+				t.Fatalf("expected %d results, got %d", len(tt.expectedTargets), len(response))
+			}
+
+			for i, result := range response {
+				if result.Target.Name != tt.expectedTargets[i] {
+					t.Errorf("result[%d]: expected target %s, got %s", i, tt.expectedTargets[i], result.Target.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthChecker_ReadinessOverride(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return nil
+		})
+
+	serve := func() int {
+		w := httptest.NewRecorder()
+		checker.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	if code := serve(); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+
+	checker.SetReadiness(false)
+
+	if code := serve(); code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d when overridden, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	checker.ResetReadiness()
+
+	if code := serve(); code != http.StatusOK {
+		t.Errorf("expected status %d after reset, got %d", http.StatusOK, code)
+	}
+}
+
+func TestHealthChecker_StartupLatches(t *testing.T) {
+	t.Parallel()
+
+	var healthy atomic.Bool
+	var calls atomic.Int32
+
+	checker := NewHealthChecker().
+		WithTarget("migrations", TargetImportanceHigh, func(ctx context.Context) error {
+			calls.Add(1)
+			if !healthy.Load() {
+				return errors.New("migrations pending")
+			}
+			return nil
+		})
+
+	serve := func() int {
+		w := httptest.NewRecorder()
+		checker.StartupHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	if code := serve(); code != http.StatusInternalServerError {
+		t.Errorf("expected status %d before startup, got %d", http.StatusInternalServerError, code)
+	}
+
+	healthy.Store(true)
+
+	if code := serve(); code != http.StatusOK {
+		t.Errorf("expected status %d after startup, got %d", http.StatusOK, code)
+	}
+
+	healthy.Store(false)
+
+	if code := serve(); code != http.StatusOK {
+		t.Errorf("expected startup probe to stay healthy, got %d", code)
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected checks to stop after startup, got %d calls", n)
+	}
+}
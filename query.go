@@ -0,0 +1,85 @@
+package status
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// resultsQuery restricts the results responded by the health handler.
+// Multiple values of a parameter match any of them, while different
+// parameters must all match.
+type resultsQuery struct {
+	targets     []string
+	importances []TargetImportance
+	groups      []string
+	tags        []string
+	verbose     bool
+}
+
+// parseResultsQuery parses the query parameters of a health request.
+func parseResultsQuery(values url.Values) (resultsQuery, error) {
+	q := resultsQuery{
+		targets: values["target"],
+		groups:  values["group"],
+		tags:    values["tag"],
+		verbose: true,
+	}
+
+	for _, value := range values["importance"] {
+		importance := TargetImportance(value)
+		if importance != TargetImportanceHigh && importance != TargetImportanceLow {
+			return resultsQuery{}, fmt.Errorf("invalid importance %q", value)
+		}
+		q.importances = append(q.importances, importance)
+	}
+
+	if value := values.Get("verbose"); value != "" {
+		verbose, err := strconv.ParseBool(value)
+		if err != nil {
+			return resultsQuery{}, fmt.Errorf("invalid verbose %q", value)
+		}
+		q.verbose = verbose
+	}
+
+	return q, nil
+}
+
+// match reports whether the target matches the query.
+func (q resultsQuery) match(target HealthTarget) bool {
+	if len(q.targets) > 0 && !slices.Contains(q.targets, target.Name) {
+		return false
+	}
+
+	if len(q.importances) > 0 && !slices.Contains(q.importances, target.Importance) {
+		return false
+	}
+
+	if len(q.groups) > 0 && !slices.Contains(q.groups, target.Group) {
+		return false
+	}
+
+	if len(q.tags) > 0 && !slices.ContainsFunc(target.Tags, func(tag string) bool {
+		return slices.Contains(q.tags, tag)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// unknownTarget returns the first of the names that is not registered with
+// the checker, if any. Disabled targets are registered.
+func (c *HealthChecker) unknownTarget(names []string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, name := range names {
+		if c.targetIndex(name) < 0 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,227 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHealthChecker_HandlerQuery(t *testing.T) {
+	t.Parallel()
+
+	check := func(ctx context.Context) error { return nil }
+
+	checker := NewHealthChecker().
+		WithTarget("postgres", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}, WithGroup("Databases"), WithTags("storage")).
+		WithTarget("redis", TargetImportanceLow, check, WithGroup("Databases"), WithTags("storage", "cache")).
+		WithTarget("payments", TargetImportanceHigh, check, WithTags("external")).
+		WithTarget("search", TargetImportanceLow, check)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedTargets []string
+	}{
+		{
+			name:            "no filters",
+			query:           "",
+			expectedStatus:  http.StatusInternalServerError,
+			expectedTargets: []string{"postgres", "redis", "payments", "search"},
+		},
+		{
+			name:            "targets",
+			query:           "?target=redis&target=payments",
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{"redis", "payments"},
+		},
+		{
+			name:            "importance",
+			query:           "?importance=high",
+			expectedStatus:  http.StatusInternalServerError,
+			expectedTargets: []string{"postgres", "payments"},
+		},
+		{
+			name:            "group",
+			query:           "?group=Databases",
+			expectedStatus:  http.StatusInternalServerError,
+			expectedTargets: []string{"postgres", "redis"},
+		},
+		{
+			name:            "tags",
+			query:           "?tag=cache&tag=external",
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{"redis", "payments"},
+		},
+		{
+			name:            "combined filters",
+			query:           "?tag=storage&importance=low",
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{"redis"},
+		},
+		{
+			name:            "no match",
+			query:           "?target=redis&importance=high",
+			expectedStatus:  http.StatusOK,
+			expectedTargets: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health"+tt.query, nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var results []HealthCheckResult
+			if err := json.NewDecoder(w.Body).Decode(&results); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			targets := make([]string, len(results))
+			for i, result := range results {
+				targets[i] = result.Target.Name
+			}
+
+			if !slices.Equal(targets, tt.expectedTargets) {
+				t.Errorf("expected targets %v, got %v", tt.expectedTargets, targets)
+			}
+		})
+	}
+}
+
+func TestHealthChecker_HandlerUnknownTarget(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil }).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	if err := checker.DisableTarget("cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "unknown target",
+			query:        "?target=database&target=dbb",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"unknown target \"dbb\""}`,
+		},
+		{
+			name:         "disabled target",
+			query:        "?target=cache",
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health"+tt.query, nil))
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("expected status %d, got %d", tt.expectedCode, w.Code)
+			}
+
+			if body := strings.TrimSpace(w.Body.String()); body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestHealthChecker_HandlerNotVerbose(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithRelease("1.2.3", "")).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	tests := []struct {
+		name         string
+		accept       string
+		expectedBody string
+	}{
+		{
+			name:         "json",
+			expectedBody: `{"status":"fail"}`,
+		},
+		{
+			name:         "health json",
+			accept:       "application/health+json",
+			expectedBody: `{"status":"fail","version":"1.2.3"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/health?verbose=false", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			checker.Handler().ServeHTTP(w, req)
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+			}
+
+			if body := strings.TrimSpace(w.Body.String()); body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestHealthChecker_HandlerInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+	tests := []struct {
+		query        string
+		expectedBody string
+	}{
+		{"?importance=critical", `{"error":"invalid importance \"critical\""}`},
+		{"?verbose=maybe", `{"error":"invalid verbose \"maybe\""}`},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		checker.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health"+tt.query, nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", tt.query, http.StatusBadRequest, w.Code)
+		}
+
+		if body := strings.TrimSpace(w.Body.String()); body != tt.expectedBody {
+			t.Errorf("%s: expected body %s, got %s", tt.query, tt.expectedBody, body)
+		}
+	}
+}
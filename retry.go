@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures retries of a failed check within a single run of
+// the target. All the attempts share the timeout of the target.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts including the first one.
+	Attempts int
+	// Backoff is the delay before the second attempt. It doubles before
+	// every next one.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between attempts. Zero means no limit.
+	MaxBackoff time.Duration
+	// Jitter randomizes every delay by up to the given fraction of it, e.g.
+	// 0.2 for ±20%.
+	Jitter float64
+	// Retryable reports whether a failed attempt should be retried. Nil
+	// retries any error.
+	Retryable func(err error) bool
+}
+
+// WithRetry makes failed checks of the target retried according to the
+// policy.
+func WithRetry(policy RetryPolicy) TargetOption {
+	return func(t *HealthTarget) {
+		t.retry = policy
+	}
+}
+
+// runAttempts runs the check of the target until it succeeds or the retry
+// policy gives up, and returns the number of attempts made. The first attempt
+// runs in the concurrency slot freed by release, while every retry acquires a
+// slot of its own, so no slot is held while waiting to retry.
+func (c *HealthChecker) runAttempts(
+	ctx context.Context, target HealthTarget, release func(),
+) (CheckReport, int, error) {
+	policy := target.retry
+	backoff := policy.Backoff
+
+	var (
+		report CheckReport
+		err    error
+	)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			next, acquireErr := c.acquire(ctx)
+			if acquireErr != nil {
+				return report, attempt - 1, err
+			}
+			release = next
+		}
+
+		report, err = runCheck(ctx, target, release)
+		if err == nil || attempt >= policy.Attempts || !policy.retryable(err) || ctx.Err() != nil {
+			return report, attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, attempt, err
+		case <-time.After(policy.jitter(backoff)):
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// retryable reports whether the failed attempt should be retried. Degraded
+// targets are never retried as they aren't failed, as well as panicked
+// checks.
+func (p RetryPolicy) retryable(err error) bool {
+	if errors.As(err, new(*degradedError)) || errors.As(err, new(*panicError)) {
+		return false
+	}
+
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// jitter randomizes the delay by up to the jitter fraction of it.
+func (p RetryPolicy) jitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return delay
+	}
+
+	return time.Duration(float64(delay) * (1 + p.Jitter*(2*rand.Float64()-1)))
+}
@@ -0,0 +1,130 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_Retry(t *testing.T) {
+	t.Parallel()
+
+	errTransient := errors.New("connection reset")
+	errPermanent := errors.New("authentication failed")
+
+	// failing returns a check failing with the errors in order and
+	// succeeding afterwards.
+	failing := func(errs ...error) (HealthCheckFunc, *atomic.Int32) {
+		var calls atomic.Int32
+		return func(ctx context.Context) error {
+			n := int(calls.Add(1))
+			if n <= len(errs) {
+				return errs[n-1]
+			}
+			return nil
+		}, &calls
+	}
+
+	tests := []struct {
+		name             string
+		errs             []error
+		policy           RetryPolicy
+		timeout          time.Duration
+		expectedStatus   HealthTargetStatus
+		expectedAttempts int
+		expectedError    string
+	}{
+		{
+			name:             "no retries by default",
+			errs:             []error{errTransient},
+			expectedStatus:   HealthTargetStatusFail,
+			expectedAttempts: 1,
+			expectedError:    "connection reset",
+		},
+		{
+			name:             "recovers after retries",
+			errs:             []error{errTransient, errTransient},
+			policy:           RetryPolicy{Attempts: 3, Backoff: time.Millisecond, Jitter: 0.5},
+			expectedStatus:   HealthTargetStatusOk,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "gives up after attempts",
+			errs:             []error{errTransient, errTransient, errTransient},
+			policy:           RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+			expectedStatus:   HealthTargetStatusFail,
+			expectedAttempts: 2,
+			expectedError:    "connection reset",
+		},
+		{
+			name: "doesn't retry non retryable errors",
+			errs: []error{errPermanent},
+			policy: RetryPolicy{Attempts: 3, Retryable: func(err error) bool {
+				return errors.Is(err, errTransient)
+			}},
+			expectedStatus:   HealthTargetStatusFail,
+			expectedAttempts: 1,
+			expectedError:    "authentication failed",
+		},
+		{
+			name:             "doesn't retry degraded targets",
+			errs:             []error{Degraded(errTransient)},
+			policy:           RetryPolicy{Attempts: 3},
+			expectedStatus:   HealthTargetStatusDegraded,
+			expectedAttempts: 1,
+			expectedError:    "connection reset",
+		},
+		{
+			name:             "retries within timeout",
+			errs:             []error{errTransient, errTransient, errTransient},
+			policy:           RetryPolicy{Attempts: 4, Backoff: 40 * time.Millisecond},
+			timeout:          50 * time.Millisecond,
+			expectedStatus:   HealthTargetStatusFail,
+			expectedAttempts: 2,
+			expectedError:    "timed out after 50ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, calls := failing(tt.errs...)
+
+			checker := NewHealthChecker(WithDefaultTimeout(tt.timeout)).
+				WithTarget("database", TargetImportanceHigh, check, WithRetry(tt.policy))
+
+			results, err := checker.Check(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := results[0]
+
+			if result.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, result.Status)
+			}
+
+			if result.Attempts != tt.expectedAttempts || int(calls.Load()) != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d (%d calls)", tt.expectedAttempts, result.Attempts, calls.Load())
+			}
+
+			if !strings.Contains(result.ErrorMessage, tt.expectedError) {
+				t.Errorf("expected error containing %q, got %q", tt.expectedError, result.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Jitter: 0.2}
+
+	for range 100 {
+		if delay := policy.jitter(time.Second); delay < 800*time.Millisecond || delay > 1200*time.Millisecond {
+			t.Fatalf("expected delay within 20%% of 1s, got %s", delay)
+		}
+	}
+}
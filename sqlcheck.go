@@ -0,0 +1,141 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SQLCheckOption is a function that configures SQLCheck.
+type SQLCheckOption func(*sqlCheck)
+
+// WithSQLQuery makes the check run the validation query, e.g. "SELECT 1",
+// instead of pinging the database.
+func WithSQLQuery(query string) SQLCheckOption {
+	return func(c *sqlCheck) {
+		c.query = query
+	}
+}
+
+// WithSQLMaxInUse fails the check when more than n connections are in use.
+func WithSQLMaxInUse(n int) SQLCheckOption {
+	return func(c *sqlCheck) {
+		c.maxInUse = n
+	}
+}
+
+// WithSQLMaxWaitCount fails the check when more than n connections were
+// waited for since the previous check.
+func WithSQLMaxWaitCount(n int64) SQLCheckOption {
+	return func(c *sqlCheck) {
+		c.maxWaitCount = n
+	}
+}
+
+// WithSQLMaxWaitDuration fails the check when the time spent waiting for
+// connections since the previous check exceeds d.
+func WithSQLMaxWaitDuration(d time.Duration) SQLCheckOption {
+	return func(c *sqlCheck) {
+		c.maxWaitDuration = d
+	}
+}
+
+// sqlCheck is the configuration and the state of SQLCheck.
+type sqlCheck struct {
+	db              *sql.DB
+	query           string
+	maxInUse        int
+	maxWaitCount    int64
+	maxWaitDuration time.Duration
+
+	mu   sync.Mutex
+	last sql.DBStats
+}
+
+// SQLCheck returns a HealthCheckFunc that pings the database or runs a
+// validation query. It reports the connection pool statistics as details of
+// the result and fails when the configured pool thresholds are exceeded.
+func SQLCheck(db *sql.DB, opts ...SQLCheckOption) HealthCheckFunc {
+	c := &sqlCheck{
+		db: db,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c.check
+}
+
+func (c *sqlCheck) check(ctx context.Context) error {
+	// The pool statistics are recorded before probing as well, as they matter
+	// most when the probe fails or hangs waiting for a connection.
+	recordSQLStats(ctx, c.db.Stats())
+
+	probeErr := c.probe(ctx)
+
+	stats := c.db.Stats()
+	recordSQLStats(ctx, stats)
+
+	c.mu.Lock()
+	waitCount := stats.WaitCount - c.last.WaitCount
+	waitDuration := stats.WaitDuration - c.last.WaitDuration
+	c.last = stats
+	c.mu.Unlock()
+
+	if probeErr != nil {
+		return probeErr
+	}
+
+	if c.maxInUse > 0 && stats.InUse > c.maxInUse {
+		return fmt.Errorf("%d connections in use exceed %d", stats.InUse, c.maxInUse)
+	}
+
+	if c.maxWaitCount > 0 && waitCount > c.maxWaitCount {
+		return fmt.Errorf("%d connection waits exceed %d", waitCount, c.maxWaitCount)
+	}
+
+	if c.maxWaitDuration > 0 && waitDuration > c.maxWaitDuration {
+		return fmt.Errorf("connection wait duration %s exceeds %s", waitDuration, c.maxWaitDuration)
+	}
+
+	return nil
+}
+
+// recordSQLStats records the connection pool statistics as details of the
+// result.
+func recordSQLStats(ctx context.Context, stats sql.DBStats) {
+	SetDetail(ctx, "max_open_connections", stats.MaxOpenConnections)
+	SetDetail(ctx, "open_connections", stats.OpenConnections)
+	SetDetail(ctx, "in_use", stats.InUse)
+	SetDetail(ctx, "idle", stats.Idle)
+	SetDetail(ctx, "wait_count", stats.WaitCount)
+	SetDetail(ctx, "wait_duration", stats.WaitDuration.String())
+}
+
+// probe pings the database or runs the validation query.
+func (c *sqlCheck) probe(ctx context.Context) error {
+	if c.query == "" {
+		if err := c.db.PingContext(ctx); err != nil {
+			return fmt.Errorf("pinging database: %w", err)
+		}
+		return nil
+	}
+
+	rows, err := c.db.QueryContext(ctx, c.query)
+	if err != nil {
+		return fmt.Errorf("running validation query: %w", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("closing validation query rows: %w", err)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reading validation query rows: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		driver   *fakeDriver
+		opts     []SQLCheckOption
+		hold     int
+		expected string
+	}{
+		{
+			name:   "ping",
+			driver: &fakeDriver{},
+		},
+		{
+			name:     "ping failure",
+			driver:   &fakeDriver{pingErr: errors.New("connection refused")},
+			expected: "pinging database: connection refused",
+		},
+		{
+			name:   "validation query",
+			driver: &fakeDriver{},
+			opts:   []SQLCheckOption{WithSQLQuery("SELECT 1")},
+		},
+		{
+			name:     "validation query failure",
+			driver:   &fakeDriver{queryErr: errors.New("relation does not exist")},
+			opts:     []SQLCheckOption{WithSQLQuery("SELECT 1 FROM migrations")},
+			expected: "running validation query: relation does not exist",
+		},
+		{
+			name:     "in use threshold exceeded",
+			driver:   &fakeDriver{},
+			opts:     []SQLCheckOption{WithSQLMaxInUse(1)},
+			hold:     2,
+			expected: "2 connections in use exceed 1",
+		},
+		{
+			name:   "in use threshold not exceeded",
+			driver: &fakeDriver{},
+			opts:   []SQLCheckOption{WithSQLMaxInUse(2)},
+			hold:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sql.OpenDB(tt.driver)
+			defer db.Close()
+
+			for range tt.hold {
+				conn, err := db.Conn(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				defer conn.Close()
+			}
+
+			checker := NewHealthChecker().
+				WithTarget("database", TargetImportanceHigh, SQLCheck(db, tt.opts...))
+
+			results, err := checker.Check(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := results[0]
+
+			if tt.expected == "" {
+				if result.Status != HealthTargetStatusOk {
+					t.Errorf("unexpected failure: %s", result.ErrorMessage)
+				}
+			} else if !strings.Contains(result.ErrorMessage, tt.expected) {
+				t.Errorf("expected error containing %q, got %q", tt.expected, result.ErrorMessage)
+			}
+
+			for _, key := range []string{"open_connections", "in_use", "idle", "wait_count", "wait_duration"} {
+				if _, ok := result.Details[key]; !ok {
+					t.Errorf("expected details to contain %s, got %v", key, result.Details)
+				}
+			}
+
+			if inUse := result.Details["in_use"]; inUse != tt.hold {
+				t.Errorf("expected %d connections in use, got %v", tt.hold, inUse)
+			}
+		})
+	}
+}
+
+func TestSQLCheck_ExhaustedPool(t *testing.T) {
+	t.Parallel()
+
+	db := sql.OpenDB(&fakeDriver{})
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, SQLCheck(db), WithTargetTimeout(20*time.Millisecond))
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := results[0]
+
+	if result.Status != HealthTargetStatusFail {
+		t.Errorf("expected the ping to time out, got %s", result.Status)
+	}
+
+	if result.Details["in_use"] != 1 || result.Details["max_open_connections"] != 1 {
+		t.Errorf("expected pool statistics of the exhausted pool, got %v", result.Details)
+	}
+}
+
+func TestSetDetail_OutsideCheck(t *testing.T) {
+	t.Parallel()
+
+	SetDetail(context.Background(), "key", "value")
+}
+
+// fakeDriver is a database/sql driver and connector with configurable
+// failures.
+type fakeDriver struct {
+	pingErr  error
+	queryErr error
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) { return &fakeConn{driver: d}, nil }
+func (d *fakeDriver) Driver() driver.Driver                        { return d }
+func (d *fakeDriver) Open(string) (driver.Conn, error)             { return &fakeConn{driver: d}, nil }
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (c *fakeConn) Ping(context.Context) error          { return c.driver.pingErr }
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if c.driver.queryErr != nil {
+		return nil, c.driver.queryErr
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"1"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
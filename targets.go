@@ -0,0 +1,226 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrTargetExists is returned when adding a target with the name of a
+	// registered one.
+	ErrTargetExists = errors.New("target already exists")
+	// ErrTargetNotFound is returned when no target with the given name is
+	// registered.
+	ErrTargetNotFound = errors.New("target not found")
+)
+
+// AddTarget adds a new health check target to the checker. Unlike
+// WithTarget, it is safe to call concurrently with the checks and returns an
+// error if a target with the same name exists or the dependencies of the
+// target form a cycle. When the checker is started, the target is checked in
+// the background right away.
+func (c *HealthChecker) AddTarget(
+	name string, importance TargetImportance, check HealthCheckFunc, opts ...TargetOption,
+) error {
+	return c.addTarget(HealthTarget{
+		Name:       name,
+		Importance: importance,
+		check:      check,
+	}, opts)
+}
+
+// AddDetailedTarget is like AddTarget for a target reporting details of its
+// checks.
+func (c *HealthChecker) AddDetailedTarget(
+	name string, importance TargetImportance, check DetailedHealthCheckFunc, opts ...TargetOption,
+) error {
+	return c.addTarget(HealthTarget{
+		Name:       name,
+		Importance: importance,
+		report:     check,
+	}, opts)
+}
+
+// ReplaceTarget replaces the registered target with the same name, keeping
+// its position. It returns an error if no such target exists or the
+// dependencies of the new target form a cycle. The state kept between checks
+// of the replaced target, like flap damping counters, is reset.
+func (c *HealthChecker) ReplaceTarget(
+	name string, importance TargetImportance, check HealthCheckFunc, opts ...TargetOption,
+) error {
+	return c.replaceTarget(HealthTarget{
+		Name:       name,
+		Importance: importance,
+		check:      check,
+	}, opts)
+}
+
+// ReplaceDetailedTarget is like ReplaceTarget for a target reporting details
+// of its checks.
+func (c *HealthChecker) ReplaceDetailedTarget(
+	name string, importance TargetImportance, check DetailedHealthCheckFunc, opts ...TargetOption,
+) error {
+	return c.replaceTarget(HealthTarget{
+		Name:       name,
+		Importance: importance,
+		report:     check,
+	}, opts)
+}
+
+// TargetInfo describes a registered target along with its runtime state. Its
+// Maintenance is the maintenance of the target in progress or yet to start.
+type TargetInfo struct {
+	HealthTarget
+	Enabled     bool         `json:"enabled"`
+	Muted       bool         `json:"muted"`
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+}
+
+// Targets returns all the registered targets, including the disabled ones,
+// in order of registration.
+func (c *HealthChecker) Targets() []TargetInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+
+	infos := make([]TargetInfo, len(c.targets))
+	for i, target := range c.targets {
+		infos[i] = TargetInfo{
+			HealthTarget: target,
+			Enabled:      !c.disabled[target.Name],
+			Muted:        c.muted[target.Name],
+		}
+
+		if m, ok := c.targetMaintenances[target.Name]; ok && !m.expired(now) {
+			infos[i].Maintenance = &m
+		}
+	}
+
+	return infos
+}
+
+// RemoveTarget removes the target with the given name from the checker along
+// with its cached result and metrics. Targets depending on it are no longer
+// skipped because of it. Its history is kept.
+func (c *HealthChecker) RemoveTarget(name string) error {
+	c.mu.Lock()
+	i := c.targetIndex(name)
+	if i < 0 {
+		c.mu.Unlock()
+		return fmt.Errorf("removing target %s: %w", name, ErrTargetNotFound)
+	}
+
+	c.targets = slices.Delete(c.targets, i, i+1)
+	c.stopRunner(name)
+	delete(c.disabled, name)
+	delete(c.muted, name)
+	delete(c.targetMaintenances, name)
+	c.forget(name)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// addTarget configures the target with opts and adds it to the checker.
+func (c *HealthChecker) addTarget(target HealthTarget, opts []TargetOption) error {
+	c.configureTarget(&target, opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.targetIndex(target.Name) >= 0 {
+		return fmt.Errorf("adding target %s: %w", target.Name, ErrTargetExists)
+	}
+
+	if err := c.checkCycle(target); err != nil {
+		return fmt.Errorf("adding target %s: %w", target.Name, err)
+	}
+
+	c.targets = append(c.targets, target)
+
+	if c.runners != nil {
+		c.startRunner(target, true)
+	}
+
+	return nil
+}
+
+// replaceTarget configures the target with opts and replaces the registered
+// target with the same name.
+func (c *HealthChecker) replaceTarget(target HealthTarget, opts []TargetOption) error {
+	c.configureTarget(&target, opts)
+
+	c.mu.Lock()
+	i := c.targetIndex(target.Name)
+	if i < 0 {
+		c.mu.Unlock()
+		return fmt.Errorf("replacing target %s: %w", target.Name, ErrTargetNotFound)
+	}
+
+	if err := c.checkCycle(target); err != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("replacing target %s: %w", target.Name, err)
+	}
+
+	c.targets[i] = target
+
+	if c.runners != nil && !c.disabled[target.Name] {
+		c.stopRunner(target.Name)
+		c.startRunner(target, true)
+	}
+	c.forgetState(target.Name)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// configureTarget applies the default timeout of the checker and opts to the
+// target.
+func (c *HealthChecker) configureTarget(target *HealthTarget, opts []TargetOption) {
+	target.timeout = c.timeout
+
+	for _, opt := range opts {
+		opt(target)
+	}
+}
+
+// forget drops everything the checker keeps about the target with the given
+// name besides its history.
+func (c *HealthChecker) forget(name string) {
+	c.metrics.forget(name)
+	c.forgetState(name)
+}
+
+// forgetState drops the state kept between checks of the target with the
+// given name.
+func (c *HealthChecker) forgetState(name string) {
+	c.statesMu.Lock()
+	delete(c.states, name)
+	c.statesMu.Unlock()
+}
+
+// targetIndex returns the index of the target with the given name or -1. It
+// must be called with mu held.
+func (c *HealthChecker) targetIndex(name string) int {
+	return slices.IndexFunc(c.targets, func(target HealthTarget) bool {
+		return target.Name == name
+	})
+}
+
+// targetList returns a copy of the registered targets that are enabled.
+func (c *HealthChecker) targetList() []HealthTarget {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	targets := make([]HealthTarget, 0, len(c.targets))
+	for _, target := range c.targets {
+		if !c.disabled[target.Name] {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
@@ -0,0 +1,301 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_AddTarget(t *testing.T) {
+	t.Parallel()
+
+	check := func(ctx context.Context) error { return nil }
+
+	checker := NewHealthChecker()
+
+	if err := checker.AddTarget("database", TargetImportanceHigh, check); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := checker.AddTarget("database", TargetImportanceLow, check)
+	if !errors.Is(err, ErrTargetExists) {
+		t.Errorf("expected ErrTargetExists, got %v", err)
+	}
+
+	err = checker.AddTarget("cache", TargetImportanceLow, check, WithDependsOn("cache"))
+	if err == nil || err.Error() != "adding target cache: dependency cycle cache -> cache" {
+		t.Errorf("expected dependency cycle error, got %v", err)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Target.Name != "database" {
+		t.Errorf("expected only the database result, got %+v", results)
+	}
+}
+
+func TestHealthChecker_WithTargetDuplicate(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}).
+		WithTarget("database", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Target.Importance != TargetImportanceLow || results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected the duplicate to replace the earlier target, got %+v", results)
+	}
+}
+
+func TestHealthChecker_ReplaceTarget(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil }).
+		WithTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+
+	err := checker.ReplaceTarget("database", TargetImportanceLow, func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = checker.ReplaceTarget("queue", TargetImportanceLow, func(ctx context.Context) error { return nil })
+	if !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	err = checker.ReplaceTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil },
+		WithDependsOn("database"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = checker.ReplaceTarget("database", TargetImportanceLow, func(ctx context.Context) error { return nil },
+		WithDependsOn("cache"))
+	if err == nil || err.Error() != "replacing target database: dependency cycle database -> cache -> database" {
+		t.Errorf("expected dependency cycle error, got %v", err)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Target.Name != "database" {
+		t.Fatalf("expected the replaced target to keep its position, got %+v", results)
+	}
+
+	if results[0].Status != HealthTargetStatusFail || results[0].Target.Importance != TargetImportanceLow {
+		t.Errorf("expected the replaced check to run, got %+v", results[0])
+	}
+
+	if results[1].Status != HealthTargetStatusSkipped {
+		t.Errorf("expected cache to be skipped by database, got %s", results[1].Status)
+	}
+}
+
+func TestHealthChecker_RemoveTarget(t *testing.T) {
+	t.Parallel()
+
+	check := func(ctx context.Context) error { return nil }
+
+	checker := NewHealthChecker().
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}).
+		WithTarget("cache", TargetImportanceLow, check, WithDependsOn("database"))
+
+	if err := checker.RemoveTarget("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := checker.RemoveTarget("database"); !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	results, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Target.Name != "cache" || results[0].Status != HealthTargetStatusOk {
+		t.Errorf("expected only an ok cache result, got %+v", results)
+	}
+}
+
+func TestHealthChecker_TargetsWhileStarted(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker(WithCheckInterval(time.Hour)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error { return nil })
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer checker.Stop()
+
+	err := checker.AddTarget("cache", TargetImportanceLow, func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitResults := func(expected func([]HealthCheckResult) bool) []HealthCheckResult {
+		t.Helper()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			results, err := checker.Results(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expected(results) || time.Now().After(deadline) {
+				return results
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	results := waitResults(func(results []HealthCheckResult) bool { return len(results) == 2 })
+	if len(results) != 2 || results[1].Status != HealthTargetStatusFail {
+		t.Fatalf("expected the added target to be checked right away, got %+v", results)
+	}
+
+	err = checker.ReplaceTarget("cache", TargetImportanceLow, func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results = waitResults(func(results []HealthCheckResult) bool {
+		return len(results) == 2 && results[1].Status == HealthTargetStatusOk
+	})
+	if len(results) != 2 || results[1].Status != HealthTargetStatusOk {
+		t.Fatalf("expected the replaced target to be checked right away, got %+v", results)
+	}
+
+	if err := checker.RemoveTarget("cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err = checker.Results(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Target.Name != "database" {
+		t.Errorf("expected only the database result, got %+v", results)
+	}
+}
+
+func TestHealthChecker_TargetsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	checker := NewHealthChecker()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("target-%d", i)
+			if err := checker.AddTarget(name, TargetImportanceLow, func(ctx context.Context) error { return nil }); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err := checker.RemoveTarget(name); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := checker.Check(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestHealthChecker_RemoveTargetDuringCheck(t *testing.T) {
+	t.Parallel()
+
+	var blocking atomic.Bool
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	checker := NewHealthChecker(WithCheckInterval(10*time.Millisecond)).
+		WithTarget("database", TargetImportanceHigh, func(ctx context.Context) error {
+			if blocking.Load() {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				<-release
+			}
+			return nil
+		})
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer checker.Stop()
+
+	blocking.Store(true)
+	<-started
+
+	if err := checker.RemoveTarget("database"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(release)
+
+	checker.Stop()
+
+	w := httptest.NewRecorder()
+	checker.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if strings.Contains(w.Body.String(), `name="database"`) {
+		t.Errorf("expected no metrics of the removed target, got %s", w.Body.String())
+	}
+}
+
+func TestHealthChecker_AddTargetWhileStopping(t *testing.T) {
+	t.Parallel()
+
+	for i := range 20 {
+		checker := NewHealthChecker(WithCheckInterval(time.Hour))
+
+		if err := checker.Start(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("target-%d", i)
+			if err := checker.AddTarget(name, TargetImportanceLow, func(ctx context.Context) error { return nil }); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+
+		checker.Stop()
+		wg.Wait()
+	}
+}
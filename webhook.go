@@ -0,0 +1,166 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookFormat defines the payload format of the WebhookNotifier.
+type WebhookFormat string
+
+const (
+	// WebhookFormatJSON sends the TransitionEvent as JSON.
+	WebhookFormatJSON = WebhookFormat("json")
+	// WebhookFormatSlack sends a Slack incoming webhook message.
+	WebhookFormatSlack = WebhookFormat("slack")
+)
+
+// WebhookOption is a function that configures a WebhookNotifier.
+type WebhookOption func(*WebhookNotifier)
+
+// WithWebhookFormat sets the payload format. Defaults to WebhookFormatJSON.
+func WithWebhookFormat(format WebhookFormat) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.format = format
+	}
+}
+
+// WithWebhookRetry sets the maximum number of attempts to deliver an event
+// and the delay before the second attempt, doubled before every next one.
+// Defaults to 3 attempts with 1s backoff.
+func WithWebhookRetry(attempts int, backoff time.Duration) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.attempts = attempts
+		n.backoff = backoff
+	}
+}
+
+// WithWebhookHeader adds a header to the webhook requests.
+func WithWebhookHeader(key, value string) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.header.Add(key, value)
+	}
+}
+
+// WithWebhookClient sets the client sending the webhook requests.
+func WithWebhookClient(client *http.Client) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.client = client
+	}
+}
+
+// WebhookNotifier is a Notifier posting the transition events to a webhook.
+type WebhookNotifier struct {
+	url      string
+	format   WebhookFormat
+	attempts int
+	backoff  time.Duration
+	header   http.Header
+	client   *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier posting to the url.
+func NewWebhookNotifier(url string, opts ...WebhookOption) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:      url,
+		format:   WebhookFormatJSON,
+		attempts: 3,
+		backoff:  time.Second,
+		header:   make(http.Header),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// Notify posts the event to the webhook, retrying failed deliveries.
+func (n *WebhookNotifier) Notify(ctx context.Context, event TransitionEvent) error {
+	body, err := json.Marshal(n.payload(event))
+	if err != nil {
+		return fmt.Errorf("marshaling payload: %w", err)
+	}
+
+	backoff := n.backoff
+
+	for attempt := 1; ; attempt++ {
+		err = n.post(ctx, body)
+		if err == nil || attempt >= n.attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// post sends a single webhook request.
+func (n *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, values := range n.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// slackMessage is a Slack incoming webhook payload.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// payload returns the webhook payload of the event in the configured format.
+func (n *WebhookNotifier) payload(event TransitionEvent) any {
+	if n.format != WebhookFormatSlack {
+		return event
+	}
+
+	text := fmt.Sprintf("%s *%s* changed from %s to %s",
+		slackEmoji(event.NewStatus), event.Target.Name, event.OldStatus, event.NewStatus)
+	if event.Error != "" {
+		text += ": " + event.Error
+	}
+
+	return slackMessage{Text: text}
+}
+
+func slackEmoji(status HealthTargetStatus) string {
+	switch status {
+	case HealthTargetStatusOk:
+		return ":large_green_circle:"
+	case HealthTargetStatusDegraded:
+		return ":large_yellow_circle:"
+	default:
+		return ":red_circle:"
+	}
+}
@@ -0,0 +1,128 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	event := TransitionEvent{
+		Target:    HealthTarget{Name: "database", Importance: TargetImportanceHigh},
+		OldStatus: HealthTargetStatusOk,
+		NewStatus: HealthTargetStatusFail,
+		Error:     "connection refused",
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name             string
+		failures         int32
+		opts             []WebhookOption
+		expectedError    bool
+		expectedRequests int32
+		check            func(t *testing.T, body []byte)
+	}{
+		{
+			name:             "json",
+			opts:             []WebhookOption{WithWebhookHeader("Authorization", "Bearer token")},
+			expectedRequests: 1,
+			check: func(t *testing.T, body []byte) {
+				var got TransitionEvent
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("failed to decode payload: %v", err)
+				}
+				if got.Target.Name != "database" || got.OldStatus != HealthTargetStatusOk ||
+					got.NewStatus != HealthTargetStatusFail || got.Error != "connection refused" ||
+					!got.Time.Equal(event.Time) {
+					t.Errorf("unexpected payload %+v", got)
+				}
+			},
+		},
+		{
+			name:             "slack",
+			opts:             []WebhookOption{WithWebhookFormat(WebhookFormatSlack)},
+			expectedRequests: 1,
+			check: func(t *testing.T, body []byte) {
+				var got slackMessage
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("failed to decode payload: %v", err)
+				}
+				expected := ":red_circle: *database* changed from ok to fail: connection refused"
+				if got.Text != expected {
+					t.Errorf("expected text %q, got %q", expected, got.Text)
+				}
+			},
+		},
+		{
+			name:             "retry",
+			failures:         2,
+			opts:             []WebhookOption{WithWebhookRetry(3, time.Millisecond)},
+			expectedRequests: 3,
+		},
+		{
+			name:             "retries exhausted",
+			failures:         5,
+			opts:             []WebhookOption{WithWebhookRetry(2, time.Millisecond)},
+			expectedError:    true,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			var body []byte
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+
+				if r.Method != http.MethodPost {
+					t.Errorf("expected POST, got %s", r.Method)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("expected content type application/json, got %s", ct)
+				}
+				if tt.name == "json" && r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("expected authorization header, got %q", r.Header.Get("Authorization"))
+				}
+
+				var raw json.RawMessage
+				if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+					t.Errorf("failed to decode body: %v", err)
+				}
+				body = raw
+			}))
+			defer server.Close()
+
+			notifier := NewWebhookNotifier(server.URL, tt.opts...)
+
+			err := notifier.Notify(context.Background(), event)
+			if tt.expectedError && err == nil {
+				t.Error("expected error")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, got)
+			}
+
+			if tt.check != nil {
+				tt.check(t, body)
+			}
+		})
+	}
+}